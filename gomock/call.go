@@ -19,6 +19,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Call represents an expected call to a mock.
@@ -30,14 +33,26 @@ type Call struct {
 	methodType reflect.Type // the type of the method
 	args       []Matcher    // the args
 	origin     string       // file and line number of call setup
+	clock      Clock        // the clock of the controller, for Delay
+	name       string       // human-readable label of the call, set by Named
+	rationale  string       // why the call is expected, set by Because
 
-	preReqs []*Call // prerequisite calls
+	preReqs    []*Call          // prerequisite calls
+	orderRules map[*Call]string // the ordering rules that made calls prerequisites
+	group      *oneOfGroup      // the OneOf group of the call, if any
 
 	// Expectations
 	minCalls, maxCalls int
 
 	numCalls int // actual number made
 
+	mu   sync.Mutex    // guards done, and numCalls against Done
+	done chan struct{} // closed once the call is satisfied
+
+	// sequences are the positions reached in the tuples of values of each
+	// ReturnSequence, so that scopes can restore them.
+	sequences []*atomic.Int64
+
 	// actions are called when this Call is called. Each action gets the args and
 	// can set the return values by returning a non-nil slice. Actions run in the
 	// order they are created.
@@ -52,35 +67,42 @@ func newCall(t TestHelper, receiver any, method string, methodType reflect.Type,
 	// TODO: check arity, types.
 	mArgs := make([]Matcher, len(args))
 	for i, arg := range args {
-		if m, ok := arg.(Matcher); ok {
-			mArgs[i] = m
-		} else if arg == nil {
-			// Handle nil specially so that passing a nil interface value
-			// will match the typed nils of concrete args.
-			mArgs[i] = Nil()
-		} else {
-			mArgs[i] = Eq(arg)
-		}
+		mArgs[i] = toMatcher(arg)
 	}
 
 	// callerInfo's skip should be updated if the number of calls between the user's test
 	// and this line changes, i.e. this code is wrapped in another anonymous function.
 	// 0 is us, 1 is RecordCallWithMethodType(), 2 is the generated recorder, and 3 is the user's test.
 	origin := callerInfo(3)
-	actions := []func([]any) []any{func([]any) []any {
-		// Synthesize the zero value for each of the return args' types.
-		rets := make([]any, methodType.NumOut())
-		for i := 0; i < methodType.NumOut(); i++ {
-			rets[i] = reflect.Zero(methodType.Out(i)).Interface()
-		}
-		return rets
-	}}
+	actions := []func([]any) []any{zeroReturns(methodType)}
 	return &Call{
 		t: t, receiver: receiver, method: method, methodType: methodType,
 		args: mArgs, origin: origin, minCalls: 1, maxCalls: 1, actions: actions,
 	}
 }
 
+// Named labels the expectation, so that failure messages tell it apart
+// from the other ones set up at the same place, such as in a test helper.
+//
+// Example usage:
+//
+//	mockDB.EXPECT().Get("key").Return(value, nil).Named("primary db lookup")
+func (c *Call) Named(name string) *Call {
+	c.name = name
+	return c
+}
+
+// Because records why the expectation is set, which failure messages show
+// next to its label and origin.
+//
+// Example usage:
+//
+//	mockDB.EXPECT().Get("key").Return(value, nil).Because("cache miss should trigger a load")
+func (c *Call) Because(reason string) *Call {
+	c.rationale = reason
+	return c
+}
+
 // AnyTimes allows the expectation to be called 0 or more times
 func (c *Call) AnyTimes() *Call {
 	c.minCalls, c.maxCalls = 0, 1e8 // close enough to infinity
@@ -188,22 +210,33 @@ func (c *Call) Do(f any) *Call {
 func (c *Call) Return(rets ...any) *Call {
 	c.t.Helper()
 
+	c.checkReturns("Return", rets)
+	c.addAction(func([]any) []any {
+		return rets
+	})
+
+	return c
+}
+
+// checkReturns checks that rets can be returned by the mocked method, and
+// converts them in place to the method's result types. name is the name of
+// the action, used in failure messages.
+func (c *Call) checkReturns(name string, rets []any) {
+	c.t.Helper()
+
 	mt := c.methodType
 	if len(rets) != mt.NumOut() {
-		c.t.Fatalf("wrong number of arguments to Return for %T.%v: got %d, want %d [%s]",
-			c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
+		c.t.Fatalf("wrong number of arguments to %s for %T.%v: got %d, want %d [%s]",
+			name, c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
 	}
 	for i, ret := range rets {
 		if got, want := reflect.TypeOf(ret), mt.Out(i); got == want {
 			// Identical types; nothing to do.
 		} else if got == nil {
 			// Nil needs special handling.
-			switch want.Kind() {
-			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
-				// ok
-			default:
-				c.t.Fatalf("argument %d to Return for %T.%v is nil, but %v is not nillable [%s]",
-					i, c.receiver, c.method, want, c.origin)
+			if !isNillable(want) {
+				c.t.Fatalf("argument %d to %s for %T.%v is nil, but %v is not nillable [%s]",
+					i, name, c.receiver, c.method, want, c.origin)
 			}
 		} else if got.AssignableTo(want) {
 			// Assignable type relation. Make the assignment now so that the generated code
@@ -212,18 +245,203 @@ func (c *Call) Return(rets ...any) *Call {
 			v.Set(reflect.ValueOf(ret))
 			rets[i] = v.Interface()
 		} else {
-			c.t.Fatalf("wrong type of argument %d to Return for %T.%v: %v is not assignable to %v [%s]",
-				i, c.receiver, c.method, got, want, c.origin)
+			c.t.Fatalf("wrong type of argument %d to %s for %T.%v: %v is not assignable to %v [%s]",
+				i, name, c.receiver, c.method, got, want, c.origin)
 		}
 	}
+}
+
+// ReturnSequence declares successive tuples of values to be returned by the
+// mocked function call: the first call returns the first tuple, the second
+// call returns the second one, and so on. Once the sequence is used up, the
+// last tuple is returned by every further call.
+//
+// Example usage:
+//
+//	mockObj.EXPECT().Next().ReturnSequence(
+//	    []any{1, nil},
+//	    []any{2, nil},
+//	    []any{0, io.EOF},
+//	).Times(3)
+func (c *Call) ReturnSequence(rets ...[]any) *Call {
+	c.t.Helper()
+
+	if len(rets) == 0 {
+		c.t.Fatalf("ReturnSequence for %T.%v needs at least one tuple of values [%s]",
+			c.receiver, c.method, c.origin)
+	}
+	for i := range rets {
+		c.checkReturns(fmt.Sprintf("ReturnSequence (tuple %d)", i), rets[i])
+	}
 
+	next := new(atomic.Int64)
+	c.sequences = append(c.sequences, next)
 	c.addAction(func([]any) []any {
+		i := min(int(next.Add(1))-1, len(rets)-1)
+		return rets[i]
+	})
+	return c
+}
+
+// ReturnCopy is like Return, except that slices, maps and arrays among the
+// values are deep-copied on every call. It prevents the code under test from
+// modifying the values returned to later calls.
+func (c *Call) ReturnCopy(rets ...any) *Call {
+	c.t.Helper()
+
+	c.checkReturns("ReturnCopy", rets)
+	c.addAction(func([]any) []any {
+		copies := make([]any, len(rets))
+		for i, ret := range rets {
+			if ret != nil {
+				copies[i] = deepCopy(reflect.ValueOf(ret)).Interface()
+			}
+		}
+		return copies
+	})
+	return c
+}
+
+// ReturnArg declares that the mocked function call returns its nth argument
+// as its first result. Any other results are the zero values of their
+// types.
+func (c *Call) ReturnArg(n int) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	c.checkArgIndex(fmt.Sprintf("ReturnArg(%d)", n), n)
+	if mt.NumOut() == 0 {
+		c.t.Fatalf("ReturnArg(%d) called for %T.%v, which has no results [%s]",
+			n, c.receiver, c.method, c.origin)
+	}
+	if at, rt := mt.In(n), mt.Out(0); !at.AssignableTo(rt) {
+		c.t.Fatalf("ReturnArg(%d) argument is a %v, not assignable to %v [%s]",
+			n, at, rt, c.origin)
+	}
+
+	zero := zeroReturns(mt)
+	c.addAction(func(args []any) []any {
+		rets := zero(args)
+		if args[n] != nil {
+			v := reflect.New(mt.Out(0)).Elem()
+			v.Set(reflect.ValueOf(args[n]))
+			rets[0] = v.Interface()
+		}
 		return rets
 	})
+	return c
+}
+
+// Panic declares that the mocked function call panics with v.
+func (c *Call) Panic(v any) *Call {
+	c.addAction(func([]any) []any {
+		panic(v)
+	})
+	return c
+}
+
+// Delay declares that the mocked function call takes d to complete. The
+// call waits on the Controller's Clock, so with a FakeClock it only
+// returns once the test has advanced the clock by d.
+func (c *Call) Delay(d time.Duration) *Call {
+	c.t.Helper()
+
+	if d < 0 {
+		c.t.Fatalf("Delay(%v) called with a negative duration [%s]", d, c.origin)
+	}
+	c.addAction(func([]any) []any {
+		clock := c.clock
+		if clock == nil {
+			clock = realClock{}
+		}
+		<-clock.After(d)
+		return nil
+	})
+	return c
+}
+
+// BlockUntil declares that the mocked function call blocks until ch is
+// closed or receives a value. It is useful to hold a call in flight while
+// the test inspects the state of the code under test.
+func (c *Call) BlockUntil(ch <-chan struct{}) *Call {
+	c.addAction(func([]any) []any {
+		<-ch
+		return nil
+	})
+	return c
+}
+
+// InvokeArg declares that the mocked function call invokes its nth
+// argument, which must be a function, with the given args. The results of
+// the invoked function are ignored.
+//
+// Example usage:
+//
+//	mockObj.EXPECT().Walk(gomock.Any()).InvokeArg(0, "a.txt", nil)
+func (c *Call) InvokeArg(n int, args ...any) *Call {
+	c.t.Helper()
+
+	c.checkArgIndex(fmt.Sprintf("InvokeArg(%d, ...)", n), n)
+	ft := c.methodType.In(n)
+	if ft.Kind() != reflect.Func {
+		c.t.Fatalf("InvokeArg(%d, ...) referring to argument of non-function type %v [%s]",
+			n, ft, c.origin)
+	}
+	if (ft.IsVariadic() && len(args) < ft.NumIn()-1) || (!ft.IsVariadic() && len(args) != ft.NumIn()) {
+		c.t.Fatalf("wrong number of arguments to InvokeArg(%d, ...) for %v: got %d, want %d [%s]",
+			n, ft, len(args), ft.NumIn(), c.origin)
+	}
+	vArgs := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		pt := ft.In(min(i, ft.NumIn()-1))
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			pt = pt.Elem()
+		}
+		if arg == nil {
+			if !isNillable(pt) {
+				c.t.Fatalf("argument %d to InvokeArg(%d, ...) is nil, but %v is not nillable [%s]",
+					i, n, pt, c.origin)
+			}
+			vArgs[i] = reflect.Zero(pt)
+			continue
+		}
+		if at := reflect.TypeOf(arg); !at.AssignableTo(pt) {
+			c.t.Fatalf("wrong type of argument %d to InvokeArg(%d, ...): %v is not assignable to %v [%s]",
+				i, n, at, pt, c.origin)
+		}
+		vArgs[i] = reflect.ValueOf(arg)
+	}
 
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		fn := reflect.ValueOf(args[n])
+		if !fn.IsValid() || fn.IsNil() {
+			c.t.Fatalf("InvokeArg(%d, ...) for %T.%v: the argument is a nil function [%s]",
+				n, c.receiver, c.method, c.origin)
+			return nil
+		}
+		fn.Call(vArgs)
+		return nil
+	})
 	return c
 }
 
+// checkArgIndex checks that n refers to a non-variadic argument of the
+// mocked method. action describes the action, for failure messages.
+func (c *Call) checkArgIndex(action string, n int) {
+	c.t.Helper()
+
+	mt := c.methodType
+	if n < 0 || n >= mt.NumIn() {
+		c.t.Fatalf("%s called for a method with %d args [%s]",
+			action, mt.NumIn(), c.origin)
+	}
+	if mt.IsVariadic() && n == mt.NumIn()-1 {
+		c.t.Fatalf("%s referring to the variadic argument of %T.%v, which is not supported [%s]",
+			action, c.receiver, c.method, c.origin)
+	}
+}
+
 // Times declares the exact number of times a function call is expected to be executed.
 func (c *Call) Times(n int) *Call {
 	c.minCalls, c.maxCalls = n, n
@@ -288,6 +506,13 @@ func (c *Call) isPreReq(other *Call) bool {
 // After declares that the call may only match after preReq has been exhausted.
 func (c *Call) After(preReq *Call) *Call {
 	c.t.Helper()
+	return c.after(preReq, "")
+}
+
+// after is like After, and records that preReq is a prerequisite because of
+// rule, if it isn't empty.
+func (c *Call) after(preReq *Call, rule string) *Call {
+	c.t.Helper()
 
 	if c == preReq {
 		c.t.Fatalf("A call isn't allowed to be its own prerequisite")
@@ -297,11 +522,38 @@ func (c *Call) After(preReq *Call) *Call {
 	}
 
 	c.preReqs = append(c.preReqs, preReq)
+	if rule != "" {
+		if c.orderRules == nil {
+			c.orderRules = make(map[*Call]string)
+		}
+		if _, ok := c.orderRules[preReq]; !ok {
+			c.orderRules[preReq] = rule
+		}
+	}
 	return c
 }
 
-// Returns true if the minimum number of calls have been made.
+// formatOrderRule returns the ordering rule that made preReq a prerequisite
+// of c, ready to be appended to a message, or an empty string if it was made
+// one with After.
+func (c *Call) formatOrderRule(preReq *Call) string {
+	if rule, ok := c.orderRules[preReq]; ok {
+		return "\nas required by " + rule
+	}
+	return ""
+}
+
+// Returns true if the minimum number of calls have been made, either of
+// this call or of another call of its OneOf group.
 func (c *Call) satisfied() bool {
+	if c.group != nil {
+		return c.group.satisfied()
+	}
+	return c.ownSatisfied()
+}
+
+// Returns true if the minimum number of calls have been made of this call.
+func (c *Call) ownSatisfied() bool {
 	return c.numCalls >= c.minCalls
 }
 
@@ -316,7 +568,20 @@ func (c *Call) String() string {
 		args[i] = arg.String()
 	}
 	arguments := strings.Join(args, ", ")
-	return fmt.Sprintf("%T.%v(%s) %s", c.receiver, c.method, arguments, c.origin)
+	return fmt.Sprintf("%T.%v(%s) %s", c.receiver, c.method, arguments, c.location())
+}
+
+// location returns the origin of c, followed by its label and rationale if
+// they were set.
+func (c *Call) location() string {
+	loc := c.origin
+	if c.name != "" {
+		loc += fmt.Sprintf(" %q", c.name)
+	}
+	if c.rationale != "" {
+		loc += " (because " + c.rationale + ")"
+	}
+	return loc
 }
 
 // Tests if the given call matches the expected call.
@@ -325,37 +590,45 @@ func (c *Call) matches(args []any) error {
 	if !c.methodType.IsVariadic() {
 		if len(args) != len(c.args) {
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
-				c.origin, len(args), len(c.args))
+				c.location(), len(args), len(c.args))
 		}
 
 		for i, m := range c.args {
-			if !m.Matches(args[i]) {
-				return fmt.Errorf(
-					"expected call at %s doesn't match the argument at index %d.\nGot: %v\nWant: %v",
-					c.origin, i, formatGottenArg(m, args[i]), m,
-				)
+			if ok, reason := explain(m, args[i]); !ok {
+				return &argMismatchError{
+					msg: fmt.Sprintf(
+						"expected call at %s doesn't match the argument at index %d.\nGot: %v\nWant: %v%s",
+						c.location(), i, formatGottenArg(m, args[i]), m, formatReason(reason),
+					),
+					m:   m,
+					got: args[i],
+				}
 			}
 		}
 	} else {
 		if len(c.args) < c.methodType.NumIn()-1 {
 			return fmt.Errorf("expected call at %s has the wrong number of matchers. Got: %d, want: %d",
-				c.origin, len(c.args), c.methodType.NumIn()-1)
+				c.location(), len(c.args), c.methodType.NumIn()-1)
 		}
 		if len(c.args) != c.methodType.NumIn() && len(args) != len(c.args) {
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
-				c.origin, len(args), len(c.args))
+				c.location(), len(args), len(c.args))
 		}
 		if len(args) < len(c.args)-1 {
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: greater than or equal to %d",
-				c.origin, len(args), len(c.args)-1)
+				c.location(), len(args), len(c.args)-1)
 		}
 
 		for i, m := range c.args {
 			if i < c.methodType.NumIn()-1 {
 				// Non-variadic args
-				if !m.Matches(args[i]) {
-					return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-						c.origin, strconv.Itoa(i), formatGottenArg(m, args[i]), m)
+				if ok, reason := explain(m, args[i]); !ok {
+					return &argMismatchError{
+						msg: fmt.Sprintf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v%s",
+							c.location(), strconv.Itoa(i), formatGottenArg(m, args[i]), m, formatReason(reason)),
+						m:   m,
+						got: args[i],
+					}
 				}
 				continue
 			}
@@ -379,12 +652,8 @@ func (c *Call) matches(args []any) error {
 			// matches all the remaining arguments or the lack of any.
 			// Convert the remaining arguments, if any, into a slice of the
 			// expected type.
-			vArgsType := c.methodType.In(c.methodType.NumIn() - 1)
-			vArgs := reflect.MakeSlice(vArgsType, 0, len(args)-i)
-			for _, arg := range args[i:] {
-				vArgs = reflect.Append(vArgs, reflect.ValueOf(arg))
-			}
-			if m.Matches(vArgs.Interface()) {
+			ok, reason := explain(m, c.variadicArgs(args[i:]))
+			if ok {
 				// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, gomock.Any())
 				// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, someSliceMatcher)
 				// Got Foo(a, b) want Foo(matcherA, matcherB, gomock.Any())
@@ -398,27 +667,75 @@ func (c *Call) matches(args []any) error {
 			// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, matcherC, matcherD)
 			// Got Foo(a, b, c) want Foo(matcherA, matcherB)
 
-			return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-				c.origin, strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i])
+			return &argMismatchError{
+				msg: fmt.Sprintf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v%s",
+					c.location(), strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i], formatReason(reason)),
+				m:   m,
+				got: args[i:],
+			}
+		}
+	}
+
+	// Check that no other call of the OneOf group has been chosen.
+	if c.group != nil {
+		if other := c.group.chosen(c); other != nil {
+			return fmt.Errorf("expected call at %s is in the OneOf group at %s, of which another call was already made:\n%v",
+				c.location(), c.group.origin, other)
 		}
 	}
 
 	// Check that all prerequisite calls have been satisfied.
 	for _, preReqCall := range c.preReqs {
 		if !preReqCall.satisfied() {
-			return fmt.Errorf("expected call at %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v",
-				c.origin, preReqCall, c)
+			return fmt.Errorf("expected call at %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v%s",
+				c.location(), preReqCall, c, c.formatOrderRule(preReqCall))
 		}
 	}
 
 	// Check that the call is not exhausted.
 	if c.exhausted() {
-		return fmt.Errorf("expected call at %s has already been called the max number of times", c.origin)
+		return fmt.Errorf("expected call at %s has already been called the max number of times", c.location())
 	}
 
 	return nil
 }
 
+// variadicArgs converts args into a slice of the type of the variadic
+// argument of the method.
+func (c *Call) variadicArgs(args []any) any {
+	vArgsType := c.methodType.In(c.methodType.NumIn() - 1)
+	vArgs := reflect.MakeSlice(vArgsType, 0, len(args))
+	for _, arg := range args {
+		vArgs = reflect.Append(vArgs, reflect.ValueOf(arg))
+	}
+	return vArgs.Interface()
+}
+
+// capture passes the arguments of a call that matched c to the matchers
+// that record them. The arguments are split between the matchers the same
+// way matches does.
+func (c *Call) capture(args []any) {
+	for i, m := range c.args {
+		cm, ok := m.(capturer)
+		if !c.methodType.IsVariadic() || i < c.methodType.NumIn()-1 {
+			if ok {
+				cm.capture(args[i])
+			}
+			continue
+		}
+		if i < len(args) && m.Matches(args[i]) {
+			if ok {
+				cm.capture(args[i])
+			}
+			continue
+		}
+		if ok {
+			cm.capture(c.variadicArgs(args[i:]))
+		}
+		break
+	}
+}
+
 // dropPrereqs tells the expected Call to not re-check prerequisite calls any
 // longer, and to return its current set.
 func (c *Call) dropPrereqs() (preReqs []*Call) {
@@ -428,29 +745,80 @@ func (c *Call) dropPrereqs() (preReqs []*Call) {
 }
 
 func (c *Call) call() []func([]any) []any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.numCalls++
+	if c.done != nil && c.ownSatisfied() {
+		select {
+		case <-c.done:
+		default:
+			close(c.done)
+		}
+	}
 	return c.actions
 }
 
-// InOrder declares that the given calls should occur in order.
-// It panics if the type of any of the arguments isn't *Call or a generated
-// mock with an embedded *Call.
-func InOrder(args ...any) {
-	calls := make([]*Call, 0, len(args))
-	for i := 0; i < len(args); i++ {
-		if call := getCall(args[i]); call != nil {
-			calls = append(calls, call)
-			continue
+// Done returns a channel that is closed once the call has been made the
+// minimum number of times it is expected to be made. It allows tests to wait
+// for calls made by other goroutines.
+func (c *Call) Done() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done == nil {
+		c.done = make(chan struct{})
+		if c.ownSatisfied() {
+			close(c.done)
 		}
-		panic(fmt.Sprintf(
-			"invalid argument at position %d of type %T, InOrder expects *gomock.Call or generated mock types with an embedded *gomock.Call",
-			i,
-			args[i],
-		))
 	}
-	for i := 1; i < len(calls); i++ {
-		calls[i].After(calls[i-1])
+	return c.done
+}
+
+// InOrder declares that the given calls should occur in order. Besides
+// calls, it accepts the Orderings returned by Ordered and AnyOrder, so that
+// orderings can be nested: all the calls of an Ordering must happen after
+// the calls before it, and before the calls after it.
+// It panics if the type of any of the arguments isn't *Call, *Ordering or a
+// generated mock with an embedded *Call.
+//
+// Example usage:
+//
+//	gomock.InOrder(
+//	    mockObj.EXPECT().Open(),
+//	    gomock.AnyOrder(mockObj.EXPECT().Read(), mockObj.EXPECT().Stat()),
+//	    mockObj.EXPECT().Close(),
+//	)
+func InOrder(args ...any) {
+	inOrder("InOrder", "InOrder at "+callerInfo(1), args)
+}
+
+// Ordered is like InOrder, but returns the Ordering of the calls, so that
+// it can be nested in AnyOrder or in another InOrder.
+//
+// Example usage:
+//
+//	// b happens before c, and a may happen at any point.
+//	gomock.AnyOrder(a, gomock.Ordered(b, c))
+func Ordered(args ...any) *Ordering {
+	return inOrder("Ordered", "Ordered at "+callerInfo(1), args)
+}
+
+// inOrder orders args, which are passed to fname, one after the other,
+// recording rule as the reason of each prerequisite.
+func inOrder(fname, rule string, args []any) *Ordering {
+	steps := orderings(fname, args)
+	if len(steps) == 0 {
+		return &Ordering{}
+	}
+	for i := 1; i < len(steps); i++ {
+		for _, call := range steps[i].first {
+			for _, preReq := range steps[i-1].last {
+				call.after(preReq, rule)
+			}
+		}
 	}
+	return &Ordering{first: steps[0].first, last: steps[len(steps)-1].last}
 }
 
 // getCall checks if the parameter is a *Call or a generated struct
@@ -493,10 +861,82 @@ func setMap(arg any, v reflect.Value) {
 	}
 }
 
+// isNillable returns whether nil is a valid value of type t.
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// deepCopy returns a copy of v in which slices, maps and arrays are copied
+// recursively. Other values are shared with v.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	default:
+		return v
+	}
+}
+
+// zeroReturns returns an action that synthesizes the zero value for each of
+// the return args' types of methodType.
+func zeroReturns(methodType reflect.Type) func([]any) []any {
+	return func([]any) []any {
+		rets := make([]any, methodType.NumOut())
+		for i := 0; i < methodType.NumOut(); i++ {
+			rets[i] = reflect.Zero(methodType.Out(i)).Interface()
+		}
+		return rets
+	}
+}
+
 func (c *Call) addAction(action func([]any) []any) {
 	c.actions = append(c.actions, action)
 }
 
+// formatReason returns the reason why an argument didn't match, ready to
+// be appended to a mismatch message, or an empty string if there is none.
+func formatReason(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return "\nReason: " + reason
+}
+
 func formatGottenArg(m Matcher, arg any) string {
 	got := fmt.Sprintf("%v (%T)", arg, arg)
 	if gs, ok := m.(GotFormatter); ok {
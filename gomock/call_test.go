@@ -648,4 +648,12 @@ func TestInOrder(t *testing.T) {
 		}
 		InOrder(c, a)
 	})
+	t.Run("can be used as a func(...any)", func(t *testing.T) {
+		var inOrder func(...any) = InOrder
+		c1, c2 := &Call{t: &mockTestReporter{}}, &Call{t: &mockTestReporter{}}
+		inOrder(c1, c2)
+		if len(c2.preReqs) != 1 {
+			t.Fatalf("expected 1 preReq in c2, found %d", len(c2.preReqs))
+		}
+	})
 }
@@ -16,8 +16,9 @@ package gomock
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"sync"
 )
 
@@ -29,8 +30,21 @@ type callSet struct {
 	expectedMu *sync.Mutex
 	// Calls that have been exhausted.
 	exhausted map[callSetKey][]*Call
+	// Calls that are only used when no expected call matches.
+	stubs map[callSetKey][]*Call
 	// when set to true, existing call expectations are overridden when new call expectations are made
 	allowOverride bool
+	// The expectations most recently overridden for each key, so that they
+	// can be restored if the overriding call turns out to be a stub.
+	overridden map[callSetKey]overriddenCalls
+	// when set to true, the most recently added calls are matched first
+	newestFirst bool
+}
+
+// overriddenCalls are the expectations that call overrode.
+type overriddenCalls struct {
+	call  *Call
+	calls []*Call
 }
 
 // callSetKey is the key in the maps in callSet
@@ -44,6 +58,7 @@ func newCallSet() *callSet {
 		expected:   make(map[callSetKey][]*Call),
 		expectedMu: &sync.Mutex{},
 		exhausted:  make(map[callSetKey][]*Call),
+		stubs:      make(map[callSetKey][]*Call),
 	}
 }
 
@@ -52,7 +67,9 @@ func newOverridableCallSet() *callSet {
 		expected:      make(map[callSetKey][]*Call),
 		expectedMu:    &sync.Mutex{},
 		exhausted:     make(map[callSetKey][]*Call),
+		stubs:         make(map[callSetKey][]*Call),
 		allowOverride: true,
+		overridden:    make(map[callSetKey]overriddenCalls),
 	}
 }
 
@@ -68,6 +85,9 @@ func (cs callSet) Add(call *Call) {
 		m = cs.exhausted
 	}
 	if cs.allowOverride {
+		if !call.exhausted() {
+			cs.overridden[key] = overriddenCalls{call: call, calls: m[key]}
+		}
 		m[key] = make([]*Call, 0)
 	}
 
@@ -92,61 +112,181 @@ func (cs callSet) Remove(call *Call) {
 	}
 }
 
+// Stub turns a previously added call into a stub. Stubs are only matched
+// when no expected call matches, and are never reported as failures.
+func (cs callSet) Stub(call *Call) {
+	key := callSetKey{call.receiver, call.method}
+
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	for _, m := range []map[callSetKey][]*Call{cs.expected, cs.exhausted} {
+		calls := m[key]
+		for i, c := range calls {
+			if c == call {
+				m[key] = append(calls[:i], calls[i+1:]...)
+				break
+			}
+		}
+	}
+	// A stub doesn't override anything, so bring back the expectations it
+	// displaced.
+	if o, ok := cs.overridden[key]; ok && o.call == call {
+		cs.expected[key] = append(o.calls, cs.expected[key]...)
+		delete(cs.overridden, key)
+	}
+
+	cs.stubs[key] = append(cs.stubs[key], call)
+}
+
 // FindMatch searches for a matching call. Returns error with explanation message if no call matched.
 func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, error) {
+	return findMatch([]*callSet{&cs}, receiver, method, args)
+}
+
+// findMatch is like FindMatch for several callSets, such as the ones of a
+// Controller and its scopes. The expected calls of each set are searched
+// first, in order, then their stubs.
+func findMatch(sets []*callSet, receiver any, method string, args []any) (*Call, error) {
 	key := callSetKey{receiver, method}
 
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+	for _, cs := range sets {
+		cs.expectedMu.Lock()
+		defer cs.expectedMu.Unlock()
+	}
 
-	// Search through the expected calls.
-	expected := cs.expected[key]
-	var callsErrors bytes.Buffer
-	for _, call := range expected {
-		err := call.matches(args)
-		if err != nil {
-			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
-		} else {
-			return call, nil
+	// Search through the expected calls. The candidates that reject the
+	// call are only compared argument by argument once no call matched, to
+	// keep matching cheap.
+	var candidates []candidate
+	var total int
+	for _, cs := range sets {
+		expected := cs.expected[key]
+		total += len(expected)
+		for _, call := range cs.inMatchOrder(expected) {
+			err := call.matches(args)
+			if err != nil {
+				candidates = append(candidates, candidate{call: call, err: err})
+			} else {
+				return call, nil
+			}
+		}
+	}
+
+	// Fall back to the stubs once every expected call failed to match.
+	for _, cs := range sets {
+		stubs := cs.stubs[key]
+		total += len(stubs)
+		for _, call := range cs.inMatchOrder(stubs) {
+			err := call.matches(args)
+			if err != nil {
+				candidates = append(candidates, candidate{call: call, err: err})
+			} else {
+				return call, nil
+			}
 		}
 	}
 
 	// If we haven't found a match then search through the exhausted calls so we
 	// get useful error messages.
-	exhausted := cs.exhausted[key]
-	for _, call := range exhausted {
-		if err := call.matches(args); err != nil {
-			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
-			continue
+	var exhaustedMatch bool
+	for _, cs := range sets {
+		exhausted := cs.exhausted[key]
+		total += len(exhausted)
+		for _, call := range exhausted {
+			if err := call.matches(args); err != nil {
+				candidates = append(candidates, candidate{call: call, err: err})
+				continue
+			}
+			exhaustedMatch = true
+		}
+	}
+
+	for i := range candidates {
+		candidates[i].compare(args)
+	}
+
+	var callsErrors bytes.Buffer
+	callsErrors.WriteString(formatCandidates(method, candidates, sets[0].newestFirst))
+	if exhaustedMatch {
+		if callsErrors.Len() != 0 {
+			callsErrors.WriteString("\n")
 		}
 		_, _ = fmt.Fprintf(
 			&callsErrors, "all expected calls for method %q have been exhausted", method,
 		)
 	}
 
-	if len(expected)+len(exhausted) == 0 {
+	if total == 0 {
 		_, _ = fmt.Fprintf(&callsErrors, "there are no expected calls of the method %q for that receiver", method)
 	}
 
-	return nil, errors.New(callsErrors.String())
+	return nil, &unmatchedCallError{msg: callsErrors.String(), candidates: candidates}
+}
+
+// inMatchOrder returns calls in the order they are matched in: the order
+// they were added in, or the reverse if newestFirst is set.
+func (cs callSet) inMatchOrder(calls []*Call) []*Call {
+	if !cs.newestFirst {
+		return calls
+	}
+	reversed := slices.Clone(calls)
+	slices.Reverse(reversed)
+	return reversed
+}
+
+// MethodType returns the type of the method of any call recorded for
+// receiver and method, or nil if there is none.
+func (cs callSet) MethodType(receiver any, method string) reflect.Type {
+	key := callSetKey{receiver, method}
+
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	for _, calls := range [][]*Call{cs.expected[key], cs.exhausted[key]} {
+		if len(calls) > 0 {
+			return calls[0].methodType
+		}
+	}
+	return nil
 }
 
-// Failures returns the calls that are not satisfied.
+// Failures returns the calls that are not satisfied. A OneOf group that is
+// not satisfied is returned as a single one of its calls.
 func (cs callSet) Failures() []*Call {
 	cs.expectedMu.Lock()
 	defer cs.expectedMu.Unlock()
 
 	failures := make([]*Call, 0, len(cs.expected))
+	groups := make(map[*oneOfGroup]bool)
 	for _, calls := range cs.expected {
 		for _, call := range calls {
-			if !call.satisfied() {
-				failures = append(failures, call)
+			if call.satisfied() {
+				continue
 			}
+			// Report a OneOf group once, through any of its calls.
+			if call.group != nil {
+				if groups[call.group] {
+					continue
+				}
+				groups[call.group] = true
+			}
+			failures = append(failures, call)
 		}
 	}
 	return failures
 }
 
+// Reset removes all expected and exhausted calls. Stubs are kept.
+func (cs callSet) Reset() {
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	clear(cs.expected)
+	clear(cs.exhausted)
+	clear(cs.overridden)
+}
+
 // Satisfied returns true in case all expected calls in this callSet are satisfied.
 func (cs callSet) Satisfied() bool {
 	cs.expectedMu.Lock()
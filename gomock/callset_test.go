@@ -16,6 +16,7 @@ package gomock
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -23,6 +24,18 @@ type receiverType struct{}
 
 func (receiverType) Func() {}
 
+// diffCounter is a matcher that matches nothing and counts its diffs.
+type diffCounter struct{ diffs *int }
+
+func (diffCounter) Matches(any) bool { return false }
+
+func (diffCounter) String() string { return "never" }
+
+func (m diffCounter) Diff(any) string {
+	*m.diffs++
+	return "counted"
+}
+
 func TestCallSetAdd(t *testing.T) {
 	method := "TestMethod"
 	var receiver any = "TestReceiver"
@@ -114,4 +127,72 @@ func TestCallSetFindMatch(t *testing.T) {
 			t.Fatal("expected error to have message, but was empty")
 		}
 	})
+	t.Run("exhausted after a rejection", func(t *testing.T) {
+		cs := newCallSet()
+		var receiver any = "TestReceiver"
+		method := "TestMethod"
+		methodType := reflect.TypeOf(func(string) {})
+
+		c1 := newCall(t, receiver, method, methodType, "a")
+		c2 := newCall(t, receiver, method, methodType, "b")
+		cs.exhausted = map[callSetKey][]*Call{
+			{receiver: receiver, fname: method}: {c1, c2},
+		}
+
+		_, err := cs.FindMatch(receiver, method, []any{"b"})
+		if err == nil {
+			t.Fatal("expected error, but was nil")
+		}
+		if i := strings.Index(err.Error(), "all expected calls"); i < 1 || err.Error()[i-1] != '\n' {
+			t.Errorf("the exhausted calls should be reported on their own line, got %q", err)
+		}
+	})
+
+	t.Run("rejections are only compared on failure", func(t *testing.T) {
+		cs := newCallSet()
+		var receiver any = "TestReceiver"
+		method := "TestMethod"
+		methodType := reflect.TypeOf(func(string) {})
+
+		var calls int
+		counting := Cond(func(x any) bool {
+			calls++
+			return false
+		})
+		cs.Add(newCall(t, receiver, method, methodType, counting))
+		cs.Add(newCall(t, receiver, method, methodType, "a"))
+
+		if _, err := cs.FindMatch(receiver, method, []any{"a"}); err != nil {
+			t.Fatalf("FindMatch() = %v, want a match", err)
+		}
+		if calls != 1 {
+			t.Errorf("the rejecting matcher was called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("diffs are only computed on failure", func(t *testing.T) {
+		cs := newCallSet()
+		var receiver any = "TestReceiver"
+		method := "TestMethod"
+		methodType := reflect.TypeOf(func(string) {})
+
+		var diffs int
+		cs.Add(newCall(t, receiver, method, methodType, diffCounter{&diffs}))
+		cs.Add(newCall(t, receiver, method, methodType, "a"))
+
+		if _, err := cs.FindMatch(receiver, method, []any{"a"}); err != nil {
+			t.Fatalf("FindMatch() = %v, want a match", err)
+		}
+		if diffs != 0 {
+			t.Errorf("the rejecting matcher was diffed %d times, want 0", diffs)
+		}
+
+		_, err := cs.FindMatch(receiver, method, []any{"b"})
+		if err == nil {
+			t.Fatal("FindMatch() = nil, want an error")
+		}
+		if diffs != 1 || !strings.Contains(err.Error(), "Diff:\n  counted") {
+			t.Errorf("the rejecting matcher was diffed %d times, want 1 in %q", diffs, err)
+		}
+	})
 }
@@ -0,0 +1,141 @@
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A Captor is a Matcher that records the arguments of type T it was used to
+// match, so that a test can inspect them once the mock has been called. A
+// value is only recorded when the whole call matches the expectation the
+// Captor is used in. It is safe for concurrent use.
+//
+// A Captor can be nested in All, in AnyOf, where it only records the values
+// it matched, in Field, where it records the value of the field, and in
+// another Captor. It never records anything under Not, which only matches
+// the values it rejects, nor under the matchers returned by WantFormatter
+// and GotFormatterAdapter.
+//
+// Example usage:
+//
+//	req := gomock.NewCaptor[*Request]()
+//	mockObj.EXPECT().Send(req).AnyTimes()
+//	// ...
+//	if got := req.Last(); got.ID != "42" {
+//	    t.Errorf("sent request %v, want ID 42", got)
+//	}
+type Captor[T any] struct {
+	matchers []Matcher
+
+	mu     sync.Mutex
+	values []T
+}
+
+// NewCaptor returns a Captor that matches every value of type T which is
+// also matched by all of the given matchers, if any.
+func NewCaptor[T any](ms ...Matcher) *Captor[T] {
+	return &Captor[T]{matchers: ms}
+}
+
+// Matches returns whether x is of type T and matched by all of the
+// Captor's matchers.
+func (c *Captor[T]) Matches(x any) bool {
+	if _, ok := c.typed(x); !ok {
+		return false
+	}
+	for _, m := range c.matchers {
+		if !m.Matches(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Captor[T]) String() string {
+	s := fmt.Sprintf("captures %v", reflect.TypeFor[T]())
+	if len(c.matchers) != 0 {
+		s += " that " + allMatcher{c.matchers}.String()
+	}
+	return s
+}
+
+// All returns the captured values, in the order they were captured.
+func (c *Captor[T]) All() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T(nil), c.values...)
+}
+
+// Last returns the most recently captured value, or the zero value of T if
+// none was captured.
+func (c *Captor[T]) Last() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var last T
+	if len(c.values) != 0 {
+		last = c.values[len(c.values)-1]
+	}
+	return last
+}
+
+func (c *Captor[T]) capture(x any) {
+	v, _ := c.typed(x)
+
+	c.mu.Lock()
+	c.values = append(c.values, v)
+	c.mu.Unlock()
+
+	captureAll(c.matchers, x)
+}
+
+// typed converts x to T. A nil x is converted to the zero value of T if T
+// is nillable.
+func (c *Captor[T]) typed(x any) (T, bool) {
+	if x == nil {
+		var zero T
+		return zero, isNillable(reflect.TypeFor[T]())
+	}
+	v, ok := x.(T)
+	return v, ok
+}
+
+// capturer is implemented by matchers that record the arguments of the
+// calls they matched, including the composite matchers that contain such
+// matchers.
+type capturer interface {
+	capture(x any)
+}
+
+// captureAll passes x to the matchers among ms that record the arguments
+// they matched. It must only be called once x is known to match all of ms.
+func captureAll(ms []Matcher, x any) {
+	for _, m := range ms {
+		if c, ok := m.(capturer); ok {
+			c.capture(x)
+		}
+	}
+}
+
+func (am allMatcher) capture(x any) {
+	captureAll(am.matchers, x)
+}
+
+// capture passes x to the matchers of am that matched it, since the other
+// ones played no part in the match.
+func (am anyOfMatcher) capture(x any) {
+	for _, m := range am.matchers {
+		if c, ok := m.(capturer); ok && m.Matches(x) {
+			c.capture(x)
+		}
+	}
+}
+
+func (f fieldMatcher) capture(x any) {
+	if c, ok := f.m.(capturer); ok {
+		if v, err := fieldByPath(x, f.path); err == nil {
+			c.capture(v)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package gomock_test
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCaptor(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	captor := gomock.NewCaptor[string]()
+	assertEqual(t, "", captor.Last())
+	ctrl.RecordCall(subject, "FooMethod", captor).Times(2)
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "2")
+	reporter.assertPass("Captor matches any string")
+
+	assertEqual(t, []string{"1", "2"}, captor.All())
+	assertEqual(t, "2", captor.Last())
+}
+
+func TestCaptor_InnerMatcher(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	captor := gomock.NewCaptor[string](gomock.Not("2"))
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 1)
+	ctrl.RecordCall(subject, "FooMethod", captor).AnyTimes()
+	ctrl.Call(subject, "FooMethod", "1")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "2")
+	}, "Want: captures string that not(is equal to 2 (string))")
+
+	assertEqual(t, []string{"1"}, captor.All())
+}
+
+func TestCaptor_Nested(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	all, anyOf, other := gomock.NewCaptor[string](), gomock.NewCaptor[string](gomock.Regex("^a")), gomock.NewCaptor[string]()
+	ctrl.RecordCall(subject, "FooMethod", gomock.All(gomock.Len(2), all)).Times(2)
+	ctrl.RecordCall(subject, "BarMethod", gomock.AnyOf(anyOf, gomock.Eq("b"), other)).Times(2)
+	ctrl.Call(subject, "FooMethod", "aa")
+	ctrl.Call(subject, "FooMethod", "bb")
+	ctrl.Call(subject, "BarMethod", "ab")
+	ctrl.Call(subject, "BarMethod", "b")
+
+	field := gomock.NewCaptor[int]()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Field("Number", field), 1)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 3}, 1)
+
+	negated := gomock.NewCaptor[string](gomock.Eq("x"))
+	ctrl.RecordCall(subject, "FooMethod", gomock.Not(negated))
+	ctrl.Call(subject, "FooMethod", "y")
+	reporter.assertPass("Nested captors match")
+
+	assertEqual(t, []string{"aa", "bb"}, all.All())
+	// Only the matchers of AnyOf that matched capture the value.
+	assertEqual(t, []string{"ab"}, anyOf.All())
+	assertEqual(t, []string{"ab", "b"}, other.All())
+	assertEqual(t, []int{3}, field.All())
+	// Not matches the values its Captor rejects, so nothing is captured.
+	assertEqual(t, []string(nil), negated.All())
+}
+
+func TestCaptor_OnlyCapturesMatchedCalls(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	captor := gomock.NewCaptor[TestStruct]()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", captor, 1)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 2)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 2}, 2)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 1)
+
+	assertEqual(t, []TestStruct{{Number: 1}}, captor.All())
+}
+
+func TestCaptor_Variadic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	all := gomock.NewCaptor[[]string]()
+	ctrl.RecordCall(subject, "VariadicMethod", 1, all)
+	ctrl.Call(subject, "VariadicMethod", 1, "a", "b")
+
+	first, second := gomock.NewCaptor[string](), gomock.NewCaptor[string]()
+	ctrl.RecordCall(subject, "VariadicMethod", 2, first, second)
+	ctrl.Call(subject, "VariadicMethod", 2, "c", "d")
+	reporter.assertPass("Captors match variadic arguments")
+
+	assertEqual(t, [][]string{{"a", "b"}}, all.All())
+	assertEqual(t, "c", first.Last())
+	assertEqual(t, "d", second.Last())
+}
+
+func TestCaptor_Concurrent(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	captor := gomock.NewCaptor[string]()
+	ctrl.RecordCall(subject, "FooMethod", captor).Times(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctrl.Call(subject, "FooMethod", "x")
+		}()
+	}
+	wg.Wait()
+
+	if got := len(captor.All()); got != 10 {
+		t.Errorf("len(All()) = %d, want 10", got)
+	}
+}
@@ -0,0 +1,43 @@
+package gomock_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := gomock.NewFakeClock(start)
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+	select {
+	case <-clock.After(0):
+	default:
+		t.Error("After(0) should fire immediately")
+	}
+	if got := clock.Waiters(); got != 2 {
+		t.Errorf("Waiters() = %d, want 2", got)
+	}
+
+	clock.Advance(time.Second)
+	if got := <-short; !got.Equal(start.Add(time.Second)) {
+		t.Errorf("After(time.Second) fired at %v, want %v", got, start.Add(time.Second))
+	}
+	select {
+	case <-long:
+		t.Error("After(time.Minute) fired too early")
+	default:
+	}
+	if got := clock.Waiters(); got != 1 {
+		t.Errorf("Waiters() = %d, want 1", got)
+	}
+
+	clock.Advance(time.Hour)
+	<-long
+	if got, want := clock.Now(), start.Add(time.Hour+time.Second); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
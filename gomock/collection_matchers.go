@@ -0,0 +1,395 @@
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// element is an element of a slice, array or map, along with its position.
+type element struct {
+	// at is the index or the key of the element, e.g. "index 2" or
+	// `key "foo"`.
+	at string
+	v  any
+}
+
+// elements returns the elements of x, which must be a slice, an array or a
+// map. Map entries are sorted by key so that messages are stable.
+func elements(x any) ([]element, bool) {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems := make([]element, v.Len())
+		for i := range elems {
+			elems[i] = element{at: fmt.Sprintf("index %d", i), v: v.Index(i).Interface()}
+		}
+		return elems, true
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return formatValue(keys[i]) < formatValue(keys[j])
+		})
+		elems := make([]element, len(keys))
+		for i, k := range keys {
+			elems[i] = element{at: "key " + formatValue(k), v: v.MapIndex(k).Interface()}
+		}
+		return elems, true
+	default:
+		return nil, false
+	}
+}
+
+// notCollection is the Got text for values that aren't collections.
+func notCollection(x any) string {
+	return fmt.Sprintf("%v (%T), which is not a slice, array or map", x, x)
+}
+
+type containsMatcher struct {
+	m Matcher
+	// substr is set when the matcher was created from a string, to also
+	// match strings and byte slices containing it.
+	substr *string
+}
+
+func (c containsMatcher) Matches(x any) bool {
+	if c.substr != nil {
+		if contains, ok := containsSubstring(x, *c.substr); ok {
+			return contains
+		}
+	}
+	elems, ok := elements(x)
+	if !ok {
+		return false
+	}
+	for _, e := range elems {
+		if c.m.Matches(e.v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c containsMatcher) Got(got any) string {
+	if c.substr != nil {
+		if _, ok := containsSubstring(got, *c.substr); ok {
+			return fmt.Sprintf("%q (%T)", got, got)
+		}
+	}
+	if _, ok := elements(got); !ok {
+		return notCollection(got)
+	}
+	return fmt.Sprintf("%v (%T), which has no such element", got, got)
+}
+
+func (c containsMatcher) withEqualities(eqs equalities) Matcher {
+	return containsMatcher{m: bindEqualities(c.m, eqs), substr: c.substr}
+}
+
+func (c containsMatcher) String() string {
+	if c.substr != nil {
+		return fmt.Sprintf("contains %q", *c.substr)
+	}
+	return "contains an element that " + c.m.String()
+}
+
+type eachMatcher struct {
+	m Matcher
+}
+
+func (e eachMatcher) Matches(x any) bool {
+	elems, ok := elements(x)
+	if !ok {
+		return false
+	}
+	for _, el := range elems {
+		if !e.m.Matches(el.v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e eachMatcher) Got(got any) string {
+	elems, ok := elements(got)
+	if !ok {
+		return notCollection(got)
+	}
+	for _, el := range elems {
+		if !e.m.Matches(el.v) {
+			return fmt.Sprintf("%v (%T), whose element at %s is %s", got, got, el.at, formatGottenArg(e.m, el.v))
+		}
+	}
+	return fmt.Sprintf("%v (%T)", got, got)
+}
+
+func (e eachMatcher) withEqualities(eqs equalities) Matcher {
+	return eachMatcher{bindEqualities(e.m, eqs)}
+}
+
+func (e eachMatcher) String() string {
+	return "has only elements that " + e.m.String()
+}
+
+type elementsAreMatcher struct {
+	ms []Matcher
+}
+
+func (e elementsAreMatcher) Matches(x any) bool {
+	return e.mismatch(x) == ""
+}
+
+// mismatch describes the first reason why x doesn't match, or returns an
+// empty string if it does.
+func (e elementsAreMatcher) mismatch(x any) string {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprintf("%v (%T), which is not a slice or array", x, x)
+	}
+	if v.Len() != len(e.ms) {
+		return fmt.Sprintf("%v (%T), which has %d element(s)", x, x, v.Len())
+	}
+	for i, m := range e.ms {
+		if el := v.Index(i).Interface(); !m.Matches(el) {
+			return fmt.Sprintf("%v (%T), whose element at index %d is %s", x, x, i, formatGottenArg(m, el))
+		}
+	}
+	return ""
+}
+
+func (e elementsAreMatcher) Got(got any) string {
+	if s := e.mismatch(got); s != "" {
+		return s
+	}
+	return fmt.Sprintf("%v (%T)", got, got)
+}
+
+func (e elementsAreMatcher) withEqualities(eqs equalities) Matcher {
+	return elementsAreMatcher{bindAllEqualities(e.ms, eqs)}
+}
+
+func (e elementsAreMatcher) String() string {
+	ss := make([]string, len(e.ms))
+	for i, m := range e.ms {
+		ss[i] = m.String()
+	}
+	return fmt.Sprintf("has %d element(s) that, in order: %s", len(e.ms), strings.Join(ss, "; "))
+}
+
+type hasKeyMatcher struct {
+	key Matcher
+	// value is nil for HasKey.
+	value Matcher
+}
+
+func (h hasKeyMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return false
+	}
+	iter := v.MapRange()
+	for iter.Next() {
+		if h.key.Matches(iter.Key().Interface()) && (h.value == nil || h.value.Matches(iter.Value().Interface())) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h hasKeyMatcher) Got(got any) string {
+	v := reflect.ValueOf(got)
+	if v.Kind() != reflect.Map {
+		return fmt.Sprintf("%v (%T), which is not a map", got, got)
+	}
+	if h.value != nil {
+		iter := v.MapRange()
+		for iter.Next() {
+			if h.key.Matches(iter.Key().Interface()) {
+				return fmt.Sprintf("%v (%T), whose entry at key %s is %s",
+					got, got, formatValue(iter.Key()), formatGottenArg(h.value, iter.Value().Interface()))
+			}
+		}
+	}
+	return fmt.Sprintf("%v (%T), which has no such key", got, got)
+}
+
+func (h hasKeyMatcher) withEqualities(eqs equalities) Matcher {
+	bound := hasKeyMatcher{key: bindEqualities(h.key, eqs)}
+	if h.value != nil {
+		bound.value = bindEqualities(h.value, eqs)
+	}
+	return bound
+}
+
+func (h hasKeyMatcher) String() string {
+	if h.value == nil {
+		return "has a key that " + h.key.String()
+	}
+	return fmt.Sprintf("has an entry whose key %s and whose value %s", h.key, h.value)
+}
+
+// setMatcher matches collections that are a subset or a superset of x,
+// counting duplicate elements.
+type setMatcher struct {
+	x        any
+	superset bool
+	eqs      equalities
+}
+
+func (s setMatcher) Matches(x any) bool {
+	return s.unmatched(x) == nil
+}
+
+// unmatched returns the first element that prevents x from matching, or nil
+// if x matches. For a subset, it is an element of x missing from s.x; for a
+// superset, it is an element of s.x missing from x. It returns an empty
+// element if x or s.x isn't a collection.
+func (s setMatcher) unmatched(x any) *element {
+	given, ok := elements(x)
+	if !ok {
+		return &element{}
+	}
+	set, ok := elements(s.x)
+	if !ok {
+		return &element{}
+	}
+	if s.superset {
+		given, set = set, given
+	}
+
+	used := make([]bool, len(set))
+	for i, g := range given {
+		m := eqMatcher{x: g.v, eqs: s.eqs}
+		found := false
+		for j, e := range set {
+			if !used[j] && m.Matches(e.v) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return &given[i]
+		}
+	}
+	return nil
+}
+
+func (s setMatcher) Got(got any) string {
+	if _, ok := elements(got); !ok {
+		return notCollection(got)
+	}
+	e := s.unmatched(got)
+	switch {
+	case e == nil:
+		return fmt.Sprintf("%v (%T)", got, got)
+	case s.superset:
+		return fmt.Sprintf("%v (%T), which lacks %s", got, got, getString(e.v))
+	default:
+		return fmt.Sprintf("%v (%T), whose element at %s is not in the set", got, got, e.at)
+	}
+}
+
+func (s setMatcher) withEqualities(eqs equalities) Matcher {
+	return setMatcher{x: s.x, superset: s.superset, eqs: eqs}
+}
+
+func (s setMatcher) String() string {
+	if s.superset {
+		return fmt.Sprintf("is a superset of %v", s.x)
+	}
+	return fmt.Sprintf("is a subset of %v", s.x)
+}
+
+// Contains returns a matcher that matches slices, arrays and maps with at
+// least one element, or map value, matched by x. If x isn't a Matcher, it
+// is compared with Eq. If x is a string, the matcher also matches strings
+// and byte slices that contain x.
+//
+// Example usage:
+//
+//	Contains(2).Matches([]int{1, 2, 3}) // returns true
+//	Contains(Len(3)).Matches([]string{"a", "b"}) // returns false
+//	Contains("oba").Matches("foobar") // returns true
+//	Contains("foo").Matches([]string{"foo", "bar"}) // returns true
+func Contains(x any) Matcher {
+	m := containsMatcher{m: toMatcher(x)}
+	if s, ok := x.(string); ok {
+		m.substr = &s
+	}
+	return m
+}
+
+// Each returns a matcher that matches slices, arrays and maps whose every
+// element, or map value, is matched by x. If x isn't a Matcher, it is
+// compared with Eq.
+//
+// Example usage:
+//
+//	Each(Not(Nil())).Matches([]*int{new(int)}) // returns true
+//	Each("a").Matches([]string{"a", "b"}) // returns false
+func Each(x any) Matcher {
+	return eachMatcher{toMatcher(x)}
+}
+
+// ElementsAre returns a matcher that matches slices and arrays with exactly
+// one element per argument, each matched by the argument at the same
+// index. Arguments that aren't Matchers are compared with Eq.
+//
+// Example usage:
+//
+//	ElementsAre(1, Any(), 3).Matches([]int{1, 2, 3}) // returns true
+//	ElementsAre(1, 2).Matches([]int{1, 2, 3}) // returns false
+func ElementsAre(xs ...any) Matcher {
+	ms := make([]Matcher, len(xs))
+	for i, x := range xs {
+		ms[i] = toMatcher(x)
+	}
+	return elementsAreMatcher{ms}
+}
+
+// HasKey returns a matcher that matches maps with a key matched by k. If k
+// isn't a Matcher, it is compared with Eq.
+//
+// Example usage:
+//
+//	HasKey("a").Matches(map[string]int{"a": 1}) // returns true
+func HasKey(k any) Matcher {
+	return hasKeyMatcher{key: toMatcher(k)}
+}
+
+// HasEntry returns a matcher that matches maps with an entry whose key is
+// matched by k and whose value is matched by v. Arguments that aren't
+// Matchers are compared with Eq.
+//
+// Example usage:
+//
+//	HasEntry("a", 1).Matches(map[string]int{"a": 1}) // returns true
+//	HasEntry("a", Not(1)).Matches(map[string]int{"a": 1}) // returns false
+func HasEntry(k, v any) Matcher {
+	return hasKeyMatcher{key: toMatcher(k), value: toMatcher(v)}
+}
+
+// SubsetOf returns a matcher that matches slices, arrays and maps whose
+// elements, or map values, can all be found in the collection x. An
+// element found once in x can only be matched once.
+//
+// Example usage:
+//
+//	SubsetOf([]int{1, 2, 3}).Matches([]int{3, 1}) // returns true
+//	SubsetOf([]int{1, 2, 3}).Matches([]int{1, 1}) // returns false
+func SubsetOf(x any) Matcher {
+	return setMatcher{x: x}
+}
+
+// SupersetOf returns a matcher that matches slices, arrays and maps which
+// hold all the elements, or map values, of the collection x. An element
+// found once in the argument can only be matched once.
+//
+// Example usage:
+//
+//	SupersetOf([]int{1, 2}).Matches([]int{3, 2, 1}) // returns true
+//	SupersetOf([]int{1, 2}).Matches([]int{1, 3}) // returns false
+func SupersetOf(x any) Matcher {
+	return setMatcher{x: x, superset: true}
+}
@@ -0,0 +1,42 @@
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCollectionMatchers_Got(t *testing.T) {
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		x       any
+		want    string
+	}{
+		{gomock.Contains(4), []int{1, 2}, "[1 2] ([]int), which has no such element"},
+		{gomock.Contains(4), 4, "4 (int), which is not a slice, array or map"},
+		{gomock.Each(gomock.Not(0)), []int{1, 0}, "[1 0] ([]int), whose element at index 1 is 0 (int)"},
+		{gomock.Each(gomock.Not(0)), map[string]int{"a": 0}, `map[a:0] (map[string]int), whose element at key "a" is 0 (int)`},
+		{gomock.ElementsAre(1, 3), []int{1, 2}, "[1 2] ([]int), whose element at index 1 is 2 (int)"},
+		{gomock.ElementsAre(1, 3), []int{1}, "[1] ([]int), which has 1 element(s)"},
+		{gomock.HasKey("b"), map[string]int{"a": 1}, "map[a:1] (map[string]int), which has no such key"},
+		{gomock.HasEntry("a", 2), map[string]int{"a": 1}, `map[a:1] (map[string]int), whose entry at key "a" is 1 (int)`},
+		{gomock.SubsetOf([]int{1, 2}), []int{2, 3}, "[2 3] ([]int), whose element at index 1 is not in the set"},
+		{gomock.SupersetOf([]int{1, 2}), []int{2, 3}, "[2 3] ([]int), which lacks 1"},
+	} {
+		t.Run(tt.matcher.String(), func(t *testing.T) {
+			if got := tt.matcher.(gomock.GotFormatter).Got(tt.x); got != tt.want {
+				t.Errorf("Got(%v) = %q, want %q", tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectionMatchers_String(t *testing.T) {
+	assertEqual(t, "contains an element that is equal to 1 (int)", gomock.Contains(1).String())
+	assertEqual(t, "has only elements that not(is nil)", gomock.Each(gomock.Not(gomock.Nil())).String())
+	assertEqual(t, "has 2 element(s) that, in order: is equal to 1 (int); is anything", gomock.ElementsAre(1, gomock.Any()).String())
+	assertEqual(t, "has a key that is equal to a (string)", gomock.HasKey("a").String())
+	assertEqual(t, "has an entry whose key is equal to a (string) and whose value is equal to 1 (int)", gomock.HasEntry("a", 1).String())
+	assertEqual(t, "is a subset of [1 2]", gomock.SubsetOf([]int{1, 2}).String())
+	assertEqual(t, "is a superset of [1 2]", gomock.SupersetOf([]int{1, 2}).String())
+}
@@ -0,0 +1,143 @@
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ctxMatcher struct {
+	desc string
+	fn   func(ctx context.Context) bool
+	// got describes the aspect of the context the matcher looks at.
+	got func(ctx context.Context) string
+}
+
+func (c ctxMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx != nil && c.fn(ctx)
+}
+
+func (c ctxMatcher) Got(got any) string {
+	ctx, ok := got.(context.Context)
+	if !ok || ctx == nil {
+		return fmt.Sprintf("%v (%T), which is not a context", got, got)
+	}
+	return c.got(ctx)
+}
+
+func (c ctxMatcher) String() string {
+	return c.desc
+}
+
+// describeDeadline describes the deadline of ctx relative to now.
+func describeDeadline(ctx context.Context) string {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return "context without deadline"
+	}
+	return fmt.Sprintf("context with deadline in %v", time.Until(deadline).Round(time.Millisecond))
+}
+
+type ctxValueMatcher struct {
+	key any
+	m   Matcher
+}
+
+func (c ctxValueMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx != nil && c.m.Matches(ctx.Value(c.key))
+}
+
+func (c ctxValueMatcher) Got(got any) string {
+	ctx, ok := got.(context.Context)
+	if !ok || ctx == nil {
+		return fmt.Sprintf("%v (%T), which is not a context", got, got)
+	}
+	v := ctx.Value(c.key)
+	if v == nil {
+		return fmt.Sprintf("context without value for key %v", getString(c.key))
+	}
+	return fmt.Sprintf("context with value %s for key %v", formatGottenArg(c.m, v), getString(c.key))
+}
+
+func (c ctxValueMatcher) withEqualities(eqs equalities) Matcher {
+	return ctxValueMatcher{key: c.key, m: bindEqualities(c.m, eqs)}
+}
+
+func (c ctxValueMatcher) String() string {
+	return fmt.Sprintf("is a context whose value for key %v %s", getString(c.key), c.m)
+}
+
+// CtxValue returns a matcher that matches contexts whose value for key is
+// matched by x. If x isn't a Matcher, it is compared with Eq, or with Nil
+// if it is nil.
+//
+// Example usage:
+//
+//	ctx := context.WithValue(context.Background(), userKey, "alice")
+//	CtxValue(userKey, "alice").Matches(ctx) // returns true
+//	CtxValue(userKey, Nil()).Matches(context.Background()) // returns true
+func CtxValue(key, x any) Matcher {
+	return ctxValueMatcher{key: key, m: toMatcher(x)}
+}
+
+// CtxHasDeadline returns a matcher that matches contexts with a deadline.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	CtxHasDeadline().Matches(ctx) // returns true
+//	CtxHasDeadline().Matches(context.Background()) // returns false
+func CtxHasDeadline() Matcher {
+	return ctxMatcher{
+		desc: "is a context with a deadline",
+		fn: func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		},
+		got: describeDeadline,
+	}
+}
+
+// CtxDeadlineWithin returns a matcher that matches contexts with a deadline
+// no later than d from the time of the call.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	CtxDeadlineWithin(2 * time.Second).Matches(ctx) // returns true
+//	CtxDeadlineWithin(time.Millisecond).Matches(ctx) // returns false
+func CtxDeadlineWithin(d time.Duration) Matcher {
+	return ctxMatcher{
+		desc: fmt.Sprintf("is a context with a deadline within %v", d),
+		fn: func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+			return ok && time.Until(deadline) <= d
+		},
+		got: describeDeadline,
+	}
+}
+
+// CtxNotDone returns a matcher that matches contexts that are neither
+// canceled nor past their deadline.
+//
+// Example usage:
+//
+//	CtxNotDone().Matches(context.Background()) // returns true
+func CtxNotDone() Matcher {
+	return ctxMatcher{
+		desc: "is a context that is not done",
+		fn: func(ctx context.Context) bool {
+			return ctx.Err() == nil
+		},
+		got: func(ctx context.Context) string {
+			if err := ctx.Err(); err != nil {
+				return fmt.Sprintf("context done with %q", err)
+			}
+			return "context not done"
+		},
+	}
+}
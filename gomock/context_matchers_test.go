@@ -0,0 +1,41 @@
+package gomock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+type valueKey string
+
+func TestContextMatchers_Messages(t *testing.T) {
+	bg := context.Background()
+	canceled, cancel := context.WithCancel(bg)
+	cancel()
+
+	assertEqual(t, "is a context whose value for key user is equal to alice (string)", gomock.CtxValue(valueKey("user"), "alice").String())
+	assertEqual(t, "is a context with a deadline", gomock.CtxHasDeadline().String())
+	assertEqual(t, "is a context with a deadline within 1s", gomock.CtxDeadlineWithin(time.Second).String())
+	assertEqual(t, "is a context that is not done", gomock.CtxNotDone().String())
+
+	got := func(m gomock.Matcher, x any) string { return m.(gomock.GotFormatter).Got(x) }
+	assertEqual(t, "context without value for key user", got(gomock.CtxValue(valueKey("user"), "alice"), bg))
+	assertEqual(t, "context with value bob (string) for key user",
+		got(gomock.CtxValue(valueKey("user"), "alice"), context.WithValue(bg, valueKey("user"), "bob")))
+	assertEqual(t, "context without deadline", got(gomock.CtxHasDeadline(), bg))
+	assertEqual(t, `context done with "context canceled"`, got(gomock.CtxNotDone(), canceled))
+	assertEqual(t, "1 (int), which is not a context", got(gomock.CtxNotDone(), 1))
+}
+
+func TestContextMatchers_Call(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	ctrl.RecordCall(subject, "ContextMethod", gomock.All(gomock.CtxDeadlineWithin(2*time.Hour), gomock.CtxNotDone()), "x")
+	ctrl.Call(subject, "ContextMethod", ctx, "x")
+	reporter.assertPass("context matchers")
+}
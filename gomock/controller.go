@@ -19,7 +19,10 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"runtime/debug"
+	"slices"
 	"sync"
+	"time"
 )
 
 // A TestReporter is something that can be used to report test failures.  It
@@ -71,10 +74,30 @@ type Controller struct {
 	// TestReporter passed in when creating the Controller via NewController.
 	// If the TestReporter does not implement a TestHelper it will be wrapped
 	// with a nopTestHelper.
-	T             TestHelper
-	mu            sync.Mutex
-	expectedCalls *callSet
-	finished      bool
+	T               TestHelper
+	mu              *sync.Mutex // shared with the scopes of the Controller
+	expectedCalls   *callSet
+	finished        bool
+	history         []*Invocation
+	historyLimit    int // 0 for no limit
+	goroutineIDs    bool
+	unexpected      UnexpectedCallPolicy
+	unexpectedStack bool
+	newestFirst     bool
+	finishTimeout   time.Duration
+	clock           Clock
+	equalities      equalities
+	// changed is closed and replaced whenever the expected calls might
+	// have become satisfied.
+	changed chan struct{}
+
+	// parent is the Controller this one is a scope of, if any.
+	parent *Controller
+	// scopes are the active scopes of a root Controller, outermost first.
+	scopes []*Controller
+	// saved are the callSets a scope layers its expectations over, as they
+	// were when it was created.
+	saved []callSetState
 }
 
 // NewController returns a new Controller. It is the preferred way to create a Controller.
@@ -88,11 +111,14 @@ func NewController(t TestReporter, opts ...ControllerOption) *Controller {
 	}
 	ctrl := &Controller{
 		T:             h,
+		mu:            &sync.Mutex{},
 		expectedCalls: newCallSet(),
+		clock:         realClock{},
 	}
 	for _, opt := range opts {
 		opt.apply(ctrl)
 	}
+	ctrl.expectedCalls.newestFirst = ctrl.newestFirst
 	if c, ok := isCleanuper(ctrl.T); ok {
 		c.Cleanup(func() {
 			ctrl.T.Helper()
@@ -120,6 +146,172 @@ func (o overridableExpectationsOption) apply(ctrl *Controller) {
 	ctrl.expectedCalls = newOverridableCallSet()
 }
 
+type newestExpectationsFirstOption struct{}
+
+// WithNewestExpectationsFirst makes calls match the most recently recorded
+// expectation that accepts them, instead of the first one. It allows a test
+// to record a broad default, e.g. with AnyTimes, and then narrower
+// expectations that take precedence over it. Unlike with
+// WithOverridableExpectations, the earlier expectations are kept, and still
+// match the calls that the later ones don't.
+//
+// Example usage:
+//
+//	ctrl := gomock.NewController(t, gomock.WithNewestExpectationsFirst())
+//	mockObj.EXPECT().Get(gomock.Any()).Return(nil, ErrNotFound).AnyTimes()
+//	mockObj.EXPECT().Get("key").Return(value, nil)
+func WithNewestExpectationsFirst() newestExpectationsFirstOption {
+	return newestExpectationsFirstOption{}
+}
+
+func (o newestExpectationsFirstOption) apply(ctrl *Controller) {
+	ctrl.newestFirst = true
+}
+
+// UnexpectedCallPolicy determines how a Controller reacts to a call that
+// does not match any expected call.
+type UnexpectedCallPolicy int
+
+const (
+	// FailOnUnexpectedCall aborts the test with TestReporter.Fatalf. This is
+	// the default policy.
+	FailOnUnexpectedCall UnexpectedCallPolicy = iota
+	// WarnOnUnexpectedCall reports the call with TestReporter.Errorf and
+	// returns the zero values of the method's results.
+	WarnOnUnexpectedCall
+	// IgnoreUnexpectedCall silently returns the zero values of the method's
+	// results. The call is still recorded in the Controller's History.
+	IgnoreUnexpectedCall
+)
+
+type unexpectedCallPolicyOption struct {
+	policy UnexpectedCallPolicy
+}
+
+// WithUnexpectedCallPolicy sets how the Controller reacts to calls that
+// don't match any expected call. It allows for lenient mocks, which only
+// enforce the expectations that matter to a test.
+//
+// The zero values can only be built for methods whose type is known: the
+// exported methods of the mock, and the unexported ones with at least one
+// recorded expectation. Other unexpected calls still fail the test.
+func WithUnexpectedCallPolicy(policy UnexpectedCallPolicy) unexpectedCallPolicyOption {
+	return unexpectedCallPolicyOption{policy: policy}
+}
+
+func (o unexpectedCallPolicyOption) apply(ctrl *Controller) {
+	ctrl.unexpected = o.policy
+}
+
+type unexpectedCallStackOption struct{}
+
+// WithUnexpectedCallStack adds the stack of the goroutine that made an
+// unexpected call to the report of that call.
+func WithUnexpectedCallStack() unexpectedCallStackOption {
+	return unexpectedCallStackOption{}
+}
+
+func (o unexpectedCallStackOption) apply(ctrl *Controller) {
+	ctrl.unexpectedStack = true
+}
+
+type historyLimitOption struct {
+	limit int
+}
+
+// WithHistoryLimit makes the Controller keep only the n most recent
+// invocations in its History, so that long-running tests don't accumulate
+// every call. The reports of missing calls then only mention the rejected
+// calls that are still in the History. n must be positive.
+func WithHistoryLimit(n int) historyLimitOption {
+	if n <= 0 {
+		panic(fmt.Sprintf("gomock: WithHistoryLimit(%d): the limit must be positive", n))
+	}
+	return historyLimitOption{limit: n}
+}
+
+func (o historyLimitOption) apply(ctrl *Controller) {
+	ctrl.historyLimit = o.limit
+}
+
+type goroutineIDsOption struct{}
+
+// WithGoroutineIDs records the ID of the goroutine that made each
+// invocation in the History. It is off by default, because getting the ID
+// requires a stack trace on every call to a mock.
+func WithGoroutineIDs() goroutineIDsOption {
+	return goroutineIDsOption{}
+}
+
+func (o goroutineIDsOption) apply(ctrl *Controller) {
+	ctrl.goroutineIDs = true
+}
+
+type finishTimeoutOption struct {
+	timeout time.Duration
+}
+
+// WithFinishTimeout makes Finish wait up to d for the expected calls to be
+// satisfied before reporting missing calls. It is useful when the mocks are
+// called from goroutines that may still be running when the test ends.
+func WithFinishTimeout(d time.Duration) finishTimeoutOption {
+	return finishTimeoutOption{timeout: d}
+}
+
+func (o finishTimeoutOption) apply(ctrl *Controller) {
+	ctrl.finishTimeout = o.timeout
+}
+
+type clockOption struct {
+	clock Clock
+}
+
+// WithClock sets the Clock used by the Controller, e.g. a FakeClock, so
+// that tests control how long delayed calls take.
+func WithClock(clock Clock) clockOption {
+	return clockOption{clock: clock}
+}
+
+func (o clockOption) apply(ctrl *Controller) {
+	ctrl.clock = o.clock
+}
+
+type equalityOption struct {
+	equality
+}
+
+// WithEquality registers the function that Eq matchers use to compare
+// values of type T, instead of reflect.DeepEqual or T's own Equal method.
+// If T is an interface type, the function is used for every type that
+// implements it, and for the values of two different types that both
+// implement it. When several interfaces apply, the function registered first
+// is used. It only applies to values of type T themselves, not to values
+// that contain one.
+//
+// Example usage:
+//
+//	ctrl := gomock.NewController(t, gomock.WithEquality(func(a, b *pb.Request) bool {
+//	    return proto.Equal(a, b)
+//	}))
+func WithEquality[T any](equal func(a, b T) bool) equalityOption {
+	return equalityOption{equality{
+		typ: reflect.TypeFor[T](),
+		equal: func(a, b any) bool {
+			return equal(a.(T), b.(T))
+		},
+	}}
+}
+
+func (o equalityOption) apply(ctrl *Controller) {
+	for i, e := range ctrl.equalities {
+		if e.typ == o.typ {
+			ctrl.equalities[i] = o.equality
+			return
+		}
+	}
+	ctrl.equalities = append(ctrl.equalities, o.equality)
+}
+
 type cancelReporter struct {
 	t      TestHelper
 	cancel func()
@@ -182,51 +374,123 @@ func (ctrl *Controller) RecordCall(receiver any, method string, args ...any) *Ca
 func (ctrl *Controller) RecordCallWithMethodType(receiver any, method string, methodType reflect.Type, args ...any) *Call {
 	ctrl.T.Helper()
 
-	call := newCall(ctrl.T, receiver, method, methodType, args...)
+	// The expectations go to the innermost active scope, since the mocks
+	// are bound to the root Controller.
+	ctrl.mu.Lock()
+	scope := ctrl.root().activeScope()
+	ctrl.mu.Unlock()
+
+	call := newCall(scope.T, receiver, method, methodType, args...)
+	call.clock = ctrl.clock
+	call.args = bindAllEqualities(call.args, ctrl.equalities)
 
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
-	ctrl.expectedCalls.Add(call)
+	scope.expectedCalls.Add(call)
 
 	return call
 }
 
+// Stub turns an expected call into a stub, a fallback behavior of the mock
+// rather than an expectation. A stub is only matched once no expected call
+// matches, may be called any number of times, and is never reported as
+// missing. call must be a *Call or a generated mock call wrapping one.
+//
+// Example usage:
+//
+//	ctrl.Stub(mockObj.EXPECT().Get(gomock.Any())).Return("default")
+//	mockObj.EXPECT().Get("key").Return("value")
+func (ctrl *Controller) Stub(call any) *Call {
+	ctrl.T.Helper()
+
+	c := getCall(call)
+	if c == nil {
+		ctrl.T.Fatalf("gomock: Stub expects *gomock.Call or a generated mock type with an embedded *gomock.Call, got %T", call)
+		return nil
+	}
+	c.AnyTimes()
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	ctrl.root().activeScope().expectedCalls.Stub(c)
+	ctrl.notifyChanged()
+
+	return c
+}
+
 // Call is called by a mock. It should not be called by user code.
 func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 	ctrl.T.Helper()
 
 	// Nest this code so we can use defer to make sure the lock is released.
-	actions := func() []func([]any) []any {
+	inv, actions := func() (*Invocation, []func([]any) []any) {
 		ctrl.T.Helper()
 		ctrl.mu.Lock()
 		defer ctrl.mu.Unlock()
 
-		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, args)
+		// callerInfo's skip should be updated if the number of calls between the user's test
+		// and this line changes, i.e. this code is wrapped in another anonymous function.
+		// 0 is us, 1 is controller.Call(), 2 is the generated mock, and 3 is the user's test.
+		origin := callerInfo(3)
+		root := ctrl.root()
+		inv := &Invocation{
+			Receiver: receiver,
+			Method:   method,
+			Args:     slices.Clone(args),
+			Origin:   origin,
+			scope:    root.activeScope(),
+		}
+		if root.goroutineIDs {
+			inv.Goroutine = goroutineID()
+		}
+		root.record(inv)
+
+		// Unexpected calls are reported to the innermost active scope,
+		// whose expectations are searched first.
+		t := root.activeScope().T
+		t.Helper()
+		layers := root.layers()
+		expected, err := findMatch(layers, receiver, method, args)
 		if err != nil {
-			// callerInfo's skip should be updated if the number of calls between the user's test
-			// and this line changes, i.e. this code is wrapped in another anonymous function.
-			// 0 is us, 1 is controller.Call(), 2 is the generated mock, and 3 is the user's test.
-			origin := callerInfo(3)
+			inv.Err = err
 			stringArgs := make([]string, len(args))
 			for i, arg := range args {
 				stringArgs[i] = getString(arg)
 			}
-			ctrl.T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+			if ctrl.unexpectedStack {
+				err = fmt.Errorf("%w\n\nStack:\n%s", err, debug.Stack())
+			}
+			switch ctrl.unexpected {
+			case WarnOnUnexpectedCall:
+				t.Errorf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+				return inv, ctrl.unexpectedCallActions(t, layers, receiver, method)
+			case IgnoreUnexpectedCall:
+				return inv, ctrl.unexpectedCallActions(t, layers, receiver, method)
+			default:
+				t.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+			}
 		}
+		inv.Expected = expected
+		expected.capture(args)
 
 		// Two things happen here:
 		// * the matching call no longer needs to check prerequisite calls,
 		// * and the prerequisite calls are no longer expected, so remove them.
 		preReqCalls := expected.dropPrereqs()
 		for _, preReqCall := range preReqCalls {
-			ctrl.expectedCalls.Remove(preReqCall)
+			for _, cs := range layers {
+				cs.Remove(preReqCall)
+			}
 		}
 
 		actions := expected.call()
 		if expected.exhausted() {
-			ctrl.expectedCalls.Remove(expected)
+			for _, cs := range layers {
+				cs.Remove(expected)
+			}
 		}
-		return actions
+		ctrl.notifyChanged()
+		return inv, actions
 	}()
 
 	var rets []any
@@ -236,9 +500,39 @@ func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 		}
 	}
 
+	ctrl.mu.Lock()
+	inv.Rets = rets
+	ctrl.mu.Unlock()
+
 	return rets
 }
 
+// unexpectedCallActions returns the actions to run for a call that didn't
+// match any expected call, when the Controller's policy allows it. The
+// zero values are built from the type of the method recorded in layers, or
+// found on the receiver. If neither is known, e.g. for an unexported method
+// without any expectation, the call is reported to t as fatal.
+func (ctrl *Controller) unexpectedCallActions(t TestHelper, layers []*callSet, receiver any, method string) []func([]any) []any {
+	t.Helper()
+
+	var methodType reflect.Type
+	for _, cs := range layers {
+		if methodType = cs.MethodType(receiver, method); methodType != nil {
+			break
+		}
+	}
+	if methodType == nil {
+		m := reflect.ValueOf(receiver).MethodByName(method)
+		if !m.IsValid() {
+			t.Fatalf("gomock: cannot return zero values for the unexpected call to %T.%v: "+
+				"the method type is unknown, record an expectation for it first", receiver, method)
+			return nil
+		}
+		methodType = m.Type()
+	}
+	return []func([]any) []any{zeroReturns(methodType)}
+}
+
 // Finish checks to see if all the methods that were expected to be called were called.
 // It is not idempotent and therefore can only be invoked once.
 //
@@ -252,16 +546,119 @@ func (ctrl *Controller) Finish() {
 }
 
 // Satisfied returns whether all expected calls bound to this Controller have been satisfied.
-// Calling Finish is then guaranteed to not fail due to missing calls.
+// Calling Finish is then guaranteed to not fail due to missing calls. The
+// expected calls of the active scopes of the Controller are included.
 func (ctrl *Controller) Satisfied() bool {
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
-	return ctrl.expectedCalls.Satisfied()
+	return ctrl.satisfied()
+}
+
+// satisfied returns whether all the expected calls of ctrl and of its active
+// scopes have been satisfied. ctrl.mu must be held.
+func (ctrl *Controller) satisfied() bool {
+	for _, cs := range ctrl.ownLayers() {
+		if !cs.Satisfied() {
+			return false
+		}
+	}
+	return true
+}
+
+// Checkpoint checks that all the methods expected so far were called, like
+// Finish does, and then removes every expectation so that the test can
+// continue with a fresh set. Stubs are kept. It is useful to verify each
+// phase of a long test separately.
+//
+// Unlike Finish, Checkpoint can be called any number of times.
+func (ctrl *Controller) Checkpoint() {
+	ctrl.T.Helper()
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if ctrl.hasActiveScope() {
+		ctrl.T.Fatalf("gomock: Checkpoint called on a Controller that has an active scope")
+		return
+	}
+	if failures := ctrl.reportFailures(); len(failures) != 0 {
+		ctrl.T.Fatalf("aborting test due to missing call(s) at checkpoint")
+		return
+	}
+	ctrl.expectedCalls.Reset()
+	ctrl.notifyChanged()
+}
+
+// Reset removes every expectation without checking that they were
+// satisfied. Stubs are kept.
+func (ctrl *Controller) Reset() {
+	ctrl.T.Helper()
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if ctrl.hasActiveScope() {
+		ctrl.T.Fatalf("gomock: Reset called on a Controller that has an active scope")
+		return
+	}
+	ctrl.expectedCalls.Reset()
+	ctrl.notifyChanged()
+}
+
+// WaitSatisfied blocks until all expected calls bound to this Controller,
+// including the ones of its active scopes, have been satisfied, or until ctx
+// is done. It returns ctx.Err() in the
+// latter case.
+func (ctrl *Controller) WaitSatisfied(ctx context.Context) error {
+	root := ctrl.root()
+	for {
+		ctrl.mu.Lock()
+		satisfied := ctrl.satisfied()
+		if root.changed == nil {
+			root.changed = make(chan struct{})
+		}
+		changed := root.changed
+		ctrl.mu.Unlock()
+
+		if satisfied {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// notifyChanged wakes up the goroutines blocked in WaitSatisfied. ctrl.mu
+// must be held.
+func (ctrl *Controller) notifyChanged() {
+	root := ctrl.root()
+	if root.changed != nil {
+		close(root.changed)
+		root.changed = nil
+	}
 }
 
 func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	ctrl.T.Helper()
 
+	// The active scopes of ctrl end first, like the subtests they belong to
+	// do, so that ctrl checks its own expectations as they were restored.
+	if panicErr == nil {
+		ctrl.mu.Lock()
+		scopes := slices.Clone(ctrl.nestedScopes())
+		ctrl.mu.Unlock()
+		for i := len(scopes) - 1; i >= 0; i-- {
+			scopes[i].finish(true, nil)
+		}
+	}
+
+	if panicErr == nil && ctrl.finishTimeout > 0 {
+		ctrl.waitBeforeFinish()
+	}
+
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
 
@@ -272,6 +669,7 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 		return
 	}
 	ctrl.finished = true
+	defer ctrl.leaveScope()
 
 	// Short-circuit, pass through the panic.
 	if panicErr != nil {
@@ -279,10 +677,7 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	}
 
 	// Check that all remaining expected calls are satisfied.
-	failures := ctrl.expectedCalls.Failures()
-	for _, call := range failures {
-		ctrl.T.Errorf("missing call(s) to %v", call)
-	}
+	failures := ctrl.reportFailures()
 	if len(failures) != 0 {
 		if !cleanup {
 			ctrl.T.Fatalf("aborting test due to missing call(s)")
@@ -292,6 +687,37 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	}
 }
 
+// waitBeforeFinish waits up to the finish timeout for the expected calls to
+// be satisfied, unless the Controller is already finished.
+func (ctrl *Controller) waitBeforeFinish() {
+	ctrl.mu.Lock()
+	finished := ctrl.finished
+	ctrl.mu.Unlock()
+	if finished {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ctrl.finishTimeout)
+	defer cancel()
+	_ = ctrl.WaitSatisfied(ctx)
+}
+
+// reportFailures reports every expected call that is not satisfied and
+// returns them. ctrl.mu must be held.
+func (ctrl *Controller) reportFailures() []*Call {
+	ctrl.T.Helper()
+
+	failures := ctrl.expectedCalls.Failures()
+	for _, call := range failures {
+		if call.group != nil {
+			ctrl.T.Errorf("missing call to one of the OneOf group at %s:%s", call.group.origin, ctrl.missingGroupReport(call.group))
+			continue
+		}
+		ctrl.T.Errorf("missing call(s) to %v%s", call, ctrl.missingCallReport(call))
+	}
+	return failures
+}
+
 // callerInfo returns the file:line of the call site. skip is the number
 // of stack frames to skip when reporting. 0 is callerInfo's call site.
 func callerInfo(skip int) string {
@@ -15,10 +15,12 @@
 package gomock_test
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 )
@@ -157,6 +159,16 @@ func (s *Subject) ActOnTestStructMethod(arg TestStruct, arg1 int) int {
 	return 0
 }
 
+func (s *Subject) ListMethod(arg string) ([]string, error) {
+	return nil, nil
+}
+
+func (s *Subject) ContextMethod(ctx context.Context, arg string) error {
+	return nil
+}
+
+func (s *Subject) CallbackMethod(arg string, cb func(string, ...int)) {}
+
 func (s *Subject) SetArgMethod(sliceArg []byte, ptrArg *int, mapArg map[any]any) {}
 func (s *Subject) SetArgMethodInterface(sliceArg, ptrArg, mapArg any)            {}
 
@@ -905,6 +917,143 @@ func TestOrderedCallsWithPreReqMaxUnbounded(t *testing.T) {
 	ctrl = gomock.NewController(reporter)
 }
 
+func TestAnyOrder(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.InOrder(
+		ctrl.RecordCall(subject, "FooMethod", "a"),
+		gomock.AnyOrder(
+			ctrl.RecordCall(subject, "FooMethod", "b"),
+			gomock.Ordered(
+				ctrl.RecordCall(subject, "FooMethod", "c"),
+				ctrl.RecordCall(subject, "FooMethod", "d"),
+			),
+		),
+		ctrl.RecordCall(subject, "FooMethod", "e"),
+	)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "b")
+	}, "doesn't have a prerequisite call satisfied", "as required by InOrder at", "controller_test.go")
+	ctrl.Call(subject, "FooMethod", "a")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "d")
+	}, "doesn't have a prerequisite call satisfied", "as required by Ordered at", "controller_test.go")
+	ctrl.Call(subject, "FooMethod", "c")
+	ctrl.Call(subject, "FooMethod", "b")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "e")
+	}, "doesn't have a prerequisite call satisfied:\n*gomock_test.Subject.FooMethod(is equal to d (string))")
+	ctrl.Call(subject, "FooMethod", "d")
+	ctrl.Call(subject, "FooMethod", "e")
+}
+
+func TestInSequence(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	writes, reads := gomock.NewSequence("writes"), gomock.NewSequence("reads")
+	ctrl.RecordCall(subject, "FooMethod", "write").InSequence(writes)
+	ctrl.RecordCall(subject, "BarMethod", "read").InSequence(reads)
+	ctrl.RecordCall(subject, "FooMethod", "close").InSequence(writes, reads)
+
+	ctrl.Call(subject, "FooMethod", "write")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "close")
+	}, "*gomock_test.Subject.BarMethod(is equal to read (string))",
+		"as required by sequence \"reads\"",
+		"Unsatisfied prerequisites:\n  *gomock_test.Subject.BarMethod(is equal to read (string))",
+		`required by sequence "reads")`)
+	ctrl.Call(subject, "BarMethod", "read")
+	ctrl.Call(subject, "FooMethod", "close")
+}
+
+func TestOneOf(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.InOrder(
+		gomock.OneOf(
+			ctrl.RecordCall(subject, "FooMethod", "cache"),
+			ctrl.RecordCall(subject, "BarMethod", "db"),
+		),
+		ctrl.RecordCall(subject, "FooMethod", "done"),
+	)
+
+	ctrl.Call(subject, "BarMethod", "db")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "cache")
+	}, "is in the OneOf group at", "of which another call was already made:\n*gomock_test.Subject.BarMethod(is equal to db (string))")
+	ctrl.Call(subject, "FooMethod", "done")
+	logged := len(reporter.log)
+	ctrl.Finish()
+	if len(reporter.log) != logged {
+		t.Errorf("Finish reported a satisfied OneOf group: %v", reporter.log[logged:])
+	}
+}
+
+func TestOneOf_ExclusiveOnceCalled(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.OneOf(
+		ctrl.RecordCall(subject, "FooMethod", "b").Times(2),
+		ctrl.RecordCall(subject, "BarMethod", "c").Times(2),
+	)
+	ctrl.Call(subject, "FooMethod", "b")
+	// FooMethod was chosen, even though it isn't satisfied yet.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "c")
+	}, "of which another call was already made:\n*gomock_test.Subject.FooMethod(is equal to b (string))")
+	ctrl.Call(subject, "FooMethod", "b")
+	if !ctrl.Satisfied() {
+		t.Error("the OneOf group should be satisfied by FooMethod")
+	}
+}
+
+func TestOneOf_AlreadyInGroup(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", "a")
+	gomock.OneOf(call, ctrl.RecordCall(subject, "BarMethod", "b"))
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "is already in the OneOf group at") {
+			t.Errorf("OneOf should panic for a call already in a group, got %v", r)
+		}
+	}()
+	gomock.OneOf(call, ctrl.RecordCall(subject, "BarMethod", "c"))
+}
+
+func TestOneOf_Missing(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.OneOf(
+		ctrl.RecordCall(subject, "FooMethod", "cache"),
+		ctrl.RecordCall(subject, "BarMethod", "db").Times(2),
+	)
+	ctrl.Call(subject, "BarMethod", "db")
+	reporter.assertFatal(ctrl.Finish)
+
+	report := strings.Join(reporter.log, "\n")
+	if got := strings.Count(report, " to one of the OneOf group") + strings.Count(report, "missing call(s) to"); got != 1 {
+		t.Errorf("report %q has %d missing call(s), want 1 for the whole group", report, got)
+	}
+	for _, want := range []string{
+		"missing call to one of the OneOf group at",
+		"\n  *gomock_test.Subject.FooMethod(is equal to cache (string))",
+		"(called 0 of 1 time(s))",
+		"\n  *gomock_test.Subject.BarMethod(is equal to db (string))",
+		"(called 1 of 2 time(s))",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report %q does not contain %q", report, want)
+		}
+	}
+}
+
 func TestCallAfterLoopPanic(t *testing.T) {
 	reporter := NewErrorReporter(t)
 	subject := new(Subject)
@@ -928,3 +1077,718 @@ func TestCallAfterLoopPanic(t *testing.T) {
 	})
 	ctrl = gomock.NewController(reporter)
 }
+
+func TestHistory(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	expected := ctrl.RecordCall(subject, "FooMethod", "argument").Return(5)
+	ctrl.RecordCall(subject, "BarMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "BarMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	history := ctrl.History()
+	if len(history) != 3 {
+		t.Fatalf("len(History()) = %d, want 3", len(history))
+	}
+	assertEqual(t, "FooMethod", history[0].Method)
+	assertEqual(t, []any{"argument"}, history[0].Args)
+	assertEqual(t, []any{5}, history[0].Rets)
+	if history[0].Expected != expected || history[0].Err != nil {
+		t.Errorf("History()[0] did not match the expected call: %v", history[0])
+	}
+	assertEqual(t, "BarMethod", history[1].Method)
+	if history[2].Matched() || history[2].Err == nil {
+		t.Errorf("History()[2] should record the unexpected call: %v", history[2])
+	}
+	if history[0].Origin == "" {
+		t.Errorf("History()[0] is missing the caller location: %v", history[0])
+	}
+	if history[0].Goroutine != 0 {
+		t.Errorf("History()[0].Goroutine = %d, want 0 without WithGoroutineIDs", history[0].Goroutine)
+	}
+
+	calls := ctrl.CallsTo(subject, "FooMethod")
+	if len(calls) != 2 {
+		t.Fatalf("len(CallsTo(subject, FooMethod)) = %d, want 2", len(calls))
+	}
+	if len(ctrl.CallsTo(new(Subject), "FooMethod")) != 0 {
+		t.Error("CallsTo should only return calls to the given receiver")
+	}
+}
+
+func TestHistory_CopiesArgs(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a")
+	args := []any{"a"}
+	ctrl.Call(subject, "FooMethod", args...)
+	args[0] = "b"
+	assertEqual(t, []any{"a"}, ctrl.History()[0].Args)
+}
+
+func TestWithGoroutineIDs(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithGoroutineIDs())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a")
+	ctrl.Call(subject, "FooMethod", "a")
+	if inv := ctrl.History()[0]; inv.Goroutine == 0 || !strings.Contains(inv.String(), " on goroutine ") {
+		t.Errorf("History()[0] is missing the goroutine: %v", inv)
+	}
+}
+
+func TestWithHistoryLimit(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithHistoryLimit(2))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes()
+	for _, arg := range []string{"a", "b", "c"} {
+		ctrl.Call(subject, "FooMethod", arg)
+	}
+	history := ctrl.History()
+	if len(history) != 2 {
+		t.Fatalf("len(History()) = %d, want 2", len(history))
+	}
+	assertEqual(t, []any{"b"}, history[0].Args)
+	assertEqual(t, []any{"c"}, history[1].Args)
+}
+
+func TestUnexpectedCallPolicy(t *testing.T) {
+	t.Run("warn", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.WarnOnUnexpectedCall))
+		subject := new(Subject)
+
+		rets := ctrl.Call(subject, "FooMethod", "argument")
+		assertEqual(t, []any{0}, rets)
+		reporter.assertFail("Unexpected call should be reported.")
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.IgnoreUnexpectedCall))
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").Return(1)
+		assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "2"))
+		assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+		ctrl.Call(subject, "VariadicMethod", 1, "a", "b")
+		reporter.assertPass("Unexpected calls should be ignored.")
+
+		history := ctrl.History()
+		if len(history) != 3 || history[0].Err == nil || history[2].Err == nil {
+			t.Fatalf("Unexpected calls should be recorded in the history: %v", history)
+		}
+		assertEqual(t, []any{0}, history[0].Rets)
+		assertEqual(t, []any{1}, history[1].Rets)
+	})
+
+	t.Run("unexported method", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.IgnoreUnexpectedCall))
+		subject := new(Subject)
+
+		// Mocks of unexported methods can't be found through reflection, so
+		// the method type comes from the recorded expectations.
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "unexportedMethod", "a")
+		}, "cannot return zero values", "method type is unknown")
+
+		methodType := reflect.TypeOf(func(string) int { return 0 })
+		ctrl.RecordCallWithMethodType(subject, "unexportedMethod", methodType, "a").Return(1)
+		assertEqual(t, []any{0}, ctrl.Call(subject, "unexportedMethod", "b"))
+		assertEqual(t, []any{1}, ctrl.Call(subject, "unexportedMethod", "a"))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.FailOnUnexpectedCall))
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "argument")
+		}, "Unexpected call to")
+	})
+}
+
+func TestStub(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.Stub(ctrl.RecordCall(subject, "FooMethod", gomock.Any())).Return(1)
+	ctrl.RecordCall(subject, "FooMethod", "2").Return(2)
+
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "2"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "2"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "3"))
+	if !ctrl.Satisfied() {
+		t.Error("Stubs should not count as expectations")
+	}
+	ctrl.Finish()
+	reporter.assertPass("Stubs are never required to be called.")
+}
+
+func TestStubNotCalled(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.Stub(ctrl.RecordCall(subject, "FooMethod", gomock.Any()))
+	ctrl.RecordCall(subject, "BarMethod", gomock.Any())
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+	if strings.Contains(strings.Join(reporter.log, "\n"), "FooMethod") {
+		t.Errorf("Stubs should not be reported as missing calls: %v", reporter.log)
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Checkpoint()
+	reporter.assertPass("All expectations of the first phase were met.")
+
+	// Expectations from the first phase are gone.
+	ctrl.RecordCall(subject, "FooMethod", "2").AnyTimes()
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	})
+
+	ctrl.RecordCall(subject, "BarMethod", "3")
+	reporter.assertFatal(func() {
+		ctrl.Checkpoint()
+	}, "aborting test due to missing call(s) at checkpoint")
+}
+
+func TestReset(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.Stub(ctrl.RecordCall(subject, "FooMethod", gomock.Any())).Return(1)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(2)
+	ctrl.RecordCall(subject, "BarMethod", "1")
+	ctrl.Reset()
+
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	ctrl.Finish()
+	reporter.assertPass("Reset should drop all expectations but keep stubs.")
+}
+
+func TestWaitSatisfied(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", "1").Times(2)
+	go func() {
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FooMethod", "1")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ctrl.WaitSatisfied(ctx); err != nil {
+		t.Fatalf("WaitSatisfied: %v", err)
+	}
+	select {
+	case <-call.Done():
+	default:
+		t.Error("Done should be closed once the call is satisfied")
+	}
+	reporter.assertPass("Expected calls made from another goroutine.")
+}
+
+func TestWaitSatisfiedTimeout(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", "1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := ctrl.WaitSatisfied(ctx); err != context.DeadlineExceeded {
+		t.Errorf("WaitSatisfied = %v, want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case <-call.Done():
+		t.Error("Done should not be closed before the call is made")
+	default:
+	}
+}
+
+func TestWithFinishTimeout(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithFinishTimeout(time.Second))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	go func() {
+		time.Sleep(time.Millisecond)
+		ctrl.Call(subject, "FooMethod", "1")
+	}()
+	ctrl.Finish()
+	reporter.assertPass("Finish should wait for the pending call.")
+}
+
+func TestReturnSequence(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").ReturnSequence([]any{1}, []any{2}).Times(3)
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "1"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "1"))
+	reporter.assertPass("ReturnSequence")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "2").ReturnSequence([]any{1}, []any{"2"})
+	}, "wrong type of argument 0 to ReturnSequence (tuple 1)")
+}
+
+func TestReturnArg(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), gomock.Any()).ReturnArg(1)
+	assertEqual(t, []any{5}, ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{}, 5))
+	reporter.assertPass("ReturnArg")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "1").ReturnArg(0)
+	}, "ReturnArg(0) argument is a string, not assignable to int")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "1").ReturnArg(1)
+	}, "called for a method with 1 args")
+}
+
+func TestReturnCopy(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ListMethod", "1").ReturnCopy([]string{"a", "b"}, nil).Times(2)
+	first := ctrl.Call(subject, "ListMethod", "1")[0].([]string)
+	first[0] = "changed"
+	assertEqual(t, []any{[]string{"a", "b"}, nil}, ctrl.Call(subject, "ListMethod", "1"))
+	reporter.assertPass("ReturnCopy")
+}
+
+func TestPanic(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Panic("boom")
+	defer func() {
+		if err := recover(); err != "boom" {
+			t.Errorf("recover() = %v, want boom", err)
+		}
+	}()
+	ctrl.Call(subject, "FooMethod", "1")
+}
+
+func TestBlockUntil(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	release := make(chan struct{})
+	ctrl.RecordCall(subject, "FooMethod", "1").BlockUntil(release).Return(1)
+	done := make(chan []any)
+	go func() {
+		done <- ctrl.Call(subject, "FooMethod", "1")
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("call returned before being released")
+	case <-time.After(time.Millisecond):
+	}
+	close(release)
+	assertEqual(t, []any{1}, <-done)
+	reporter.assertPass("BlockUntil")
+}
+
+func TestInvokeArg(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "CallbackMethod", "1", gomock.Any()).InvokeArg(1, "a", 1, 2)
+	var gotS string
+	var gotI []int
+	ctrl.Call(subject, "CallbackMethod", "1", func(s string, i ...int) {
+		gotS, gotI = s, i
+	})
+	assertEqual(t, "a", gotS)
+	assertEqual(t, []int{1, 2}, gotI)
+	reporter.assertPass("InvokeArg")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "CallbackMethod", "1", gomock.Any()).InvokeArg(0)
+	}, "InvokeArg(0, ...) referring to argument of non-function type string")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "CallbackMethod", "1", gomock.Any()).InvokeArg(1, 1)
+	}, "wrong type of argument 0 to InvokeArg(1, ...): int is not assignable to string")
+}
+
+func TestDelay(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	clock := gomock.NewFakeClock(time.Now())
+	ctrl := gomock.NewController(reporter, gomock.WithClock(clock))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Delay(time.Hour).Return(1)
+	done := make(chan []any)
+	go func() {
+		done <- ctrl.Call(subject, "FooMethod", "1")
+	}()
+
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Microsecond)
+	}
+	clock.Advance(time.Minute)
+	select {
+	case <-done:
+		t.Fatal("call returned before the delay elapsed")
+	case <-time.After(time.Millisecond):
+	}
+	clock.Advance(time.Hour)
+	assertEqual(t, []any{1}, <-done)
+	reporter.assertPass("Delay")
+}
+
+func TestUnexpectedArgValue_Diff(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "hello"}, 15)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 2, Message: "hello"}, 15)
+	}, "Diff:\n  .Number: got 2, want 1")
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "hello"}, 15)
+}
+
+func TestUnexpectedCall_ClosestMatchFirst(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 1)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 2)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 2}, 3)
+	}, `2 expected calls of "ActOnTestStructMethod" don't match, closest first:`,
+		"\n\n1. *gomock_test.Subject.ActOnTestStructMethod(is anything, is equal to 2 (int))",
+		"(called 0 of at most 1 time(s), 1 of 2 argument(s) match)",
+		"Arguments:\n  [0] ok: got {2 } (gomock_test.TestStruct), want is anything\n"+
+			"  [1] MISMATCH: got 3 (int), want is equal to 2 (int)",
+		"\n\n2. *gomock_test.Subject.ActOnTestStructMethod(is equal to {1 } (gomock_test.TestStruct), is equal to 1 (int))",
+		"(called 0 of at most 1 time(s), 0 of 2 argument(s) match)")
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 1)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 2}, 2)
+}
+
+func TestUnexpectedCall_UnsatisfiedPrerequisites(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	foo := ctrl.RecordCall(subject, "FooMethod", "a").Times(2)
+	ctrl.RecordCall(subject, "BarMethod", "b").After(foo).AnyTimes()
+	ctrl.Call(subject, "FooMethod", "a")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "b")
+	}, "(called 0 time(s), no limit, 1 of 1 argument(s) match)",
+		"Unsatisfied prerequisites:\n  *gomock_test.Subject.FooMethod(is equal to a (string))",
+		"(called 1 of at most 2 time(s))")
+	ctrl.Call(subject, "FooMethod", "a")
+	ctrl.Call(subject, "BarMethod", "b")
+}
+
+func TestMissingCallReport(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.WarnOnUnexpectedCall))
+	subject := new(Subject)
+
+	foo := ctrl.RecordCall(subject, "FooMethod", "a").MinTimes(2)
+	ctrl.RecordCall(subject, "BarMethod", "b").After(foo)
+	ctrl.Call(subject, "FooMethod", "a")
+	ctrl.Call(subject, "FooMethod", "c")
+	reporter.assertFatal(ctrl.Finish)
+
+	report := strings.Join(reporter.log, "\n")
+	for _, want := range []string{
+		"missing call(s) to *gomock_test.Subject.FooMethod(is equal to a (string))",
+		"(called 1 time(s), want at least 2)\nRejected call(s) to FooMethod:\n  FooMethod(c) at ",
+		"    expected call at ",
+		"    Got: c (string)\n    Want: is equal to a (string)",
+		"missing call(s) to *gomock_test.Subject.BarMethod(is equal to b (string))",
+		"(called 0 of 1 time(s))\nBlocked by unsatisfied prerequisite(s):\n  *gomock_test.Subject.FooMethod(is equal to a (string))",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report %q does not contain %q", report, want)
+		}
+	}
+	if strings.Contains(report, "Rejected call(s) to BarMethod") {
+		t.Errorf("report %q lists rejected calls to BarMethod, which was never called", report)
+	}
+}
+
+func TestWithNewestExpectationsFirst(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithNewestExpectationsFirst())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(0).AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", gomock.Any()).Return(0).AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", "b").Return(1)
+
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "BarMethod", "b"))
+	assertEqual(t, []any{0}, ctrl.Call(subject, "BarMethod", "b"))
+	assertEqual(t, []any{0}, ctrl.Call(subject, "BarMethod", "c"))
+	ctrl.Finish()
+	reporter.assertPass("newest expectations are matched first")
+}
+
+func TestWithNewestExpectationsFirst_Diagnostics(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithNewestExpectationsFirst())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a")
+	ctrl.RecordCall(subject, "FooMethod", "b")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "c")
+	}, "(expected calls are tried newest first)\n\n1. *gomock_test.Subject.FooMethod(is equal to b (string))",
+		"\n\n2. *gomock_test.Subject.FooMethod(is equal to a (string))")
+	ctrl.Call(subject, "FooMethod", "a")
+	ctrl.Call(subject, "FooMethod", "b")
+}
+
+func TestScope(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(0).AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", "shared").Return(1)
+
+	for _, arg := range []string{"a", "b"} {
+		t.Run(arg, func(t *testing.T) {
+			sub := NewErrorReporter(t)
+			t.Cleanup(func() {
+				sub.assertPass("scoped expectations were met")
+			})
+			ctrl.Scope(sub)
+
+			ctrl.RecordCall(subject, "FooMethod", arg).Return(2)
+			assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", arg))
+			assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", arg))
+			assertEqual(t, []any{1}, ctrl.Call(subject, "BarMethod", "shared"))
+		})
+	}
+
+	// The shared expectation is back, and the scoped ones are gone.
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "BarMethod", "shared"))
+	reporter.assertPass("scopes restore the expectations of their parent")
+}
+
+func TestScope_ReportsToSubtest(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var sub *ErrorReporter
+	t.Run("missing", func(t *testing.T) {
+		sub = NewErrorReporter(t)
+		ctrl.Scope(sub)
+		ctrl.RecordCall(subject, "FooMethod", "a")
+		sub.assertFatal(func() {
+			ctrl.Call(subject, "BarMethod", "b")
+		}, "Unexpected call to", "there are no expected calls of the method \"BarMethod\"")
+	})
+	if log := strings.Join(sub.log, "\n"); !strings.Contains(log, "missing call(s) to *gomock_test.Subject.FooMethod(is equal to a (string))") {
+		t.Errorf("scope did not report its missing call: %q", log)
+	}
+
+	ctrl.RecordCall(subject, "FooMethod", "c")
+	ctrl.Call(subject, "FooMethod", "c")
+	reporter.assertPass("the failures of a scope are reported to its subtest")
+}
+
+func TestScope_Nested(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a").Return(0)
+	outer := ctrl.Scope(NewErrorReporter(t))
+	ctrl.RecordCall(subject, "FooMethod", "a").Return(1)
+	inner := outer.Scope(NewErrorReporter(t))
+	ctrl.RecordCall(subject, "FooMethod", "a").Return(2)
+
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "a"))
+	inner.Finish()
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "a"))
+	outer.Finish()
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "a"))
+	reporter.assertPass("nested scopes are layered")
+}
+
+func TestScope_RestoresCallState(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", "a").ReturnSequence([]any{1}, []any{2}).Times(2)
+	done := call.Done()
+
+	scope := ctrl.Scope(NewErrorReporter(t))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "a"))
+	select {
+	case <-done:
+	default:
+		t.Fatal("Done() should be closed once the call is satisfied in the scope")
+	}
+	scope.Finish()
+
+	select {
+	case <-call.Done():
+		t.Error("Done() should be open again once the scope restored the call")
+	default:
+	}
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "a"))
+	<-call.Done()
+	reporter.assertPass("the scope restored the call")
+}
+
+func TestScope_RootOperations(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	sub := NewErrorReporter(t)
+	ctrl.Scope(sub)
+	ctrl.RecordCall(subject, "FooMethod", "a")
+
+	if ctrl.Satisfied() {
+		t.Error("Satisfied() should include the expected calls of the active scope")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ctrl.WaitSatisfied(ctx); err == nil {
+		t.Error("WaitSatisfied() should wait for the expected calls of the active scope")
+	}
+	reporter.assertFatal(ctrl.Checkpoint, "Checkpoint called on a Controller that has an active scope")
+	reporter.assertFatal(ctrl.Reset, "Reset called on a Controller that has an active scope")
+
+	// Finish ends the scope first, which reports its own missing call.
+	ctrl.Finish()
+	if log := strings.Join(sub.log, "\n"); !strings.Contains(log, "missing call(s) to *gomock_test.Subject.FooMethod(is equal to a (string))") {
+		t.Errorf("Finish did not end the active scope: %q", log)
+	}
+	if log := strings.Join(reporter.log, "\n"); strings.Contains(log, "missing call") {
+		t.Errorf("the missing call of the scope was reported to its parent: %q", log)
+	}
+}
+
+func TestScope_MissingCallReportIgnoresScopes(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallPolicy(gomock.IgnoreUnexpectedCall))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a")
+	scope := ctrl.Scope(NewErrorReporter(t))
+	ctrl.Call(subject, "FooMethod", "b")
+	scope.Finish()
+	ctrl.Call(subject, "FooMethod", "c")
+	reporter.assertFatal(ctrl.Finish)
+
+	report := strings.Join(reporter.log, "\n")
+	if !strings.Contains(report, "  FooMethod(c) at ") {
+		t.Errorf("report %q should include the call rejected by the Controller", report)
+	}
+	if strings.Contains(report, "FooMethod(b)") {
+		t.Errorf("report %q should not include the call made in the scope", report)
+	}
+	if len(ctrl.History()) != 2 {
+		t.Errorf("History() should keep the calls made in the scope: %v", ctrl.History())
+	}
+}
+
+func TestScope_Parallel(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+
+	first, second := NewErrorReporter(t), NewErrorReporter(t)
+	ctrl.Scope(first)
+	second.assertFatal(func() {
+		ctrl.Scope(second)
+	}, "Scope called on a Controller that has an active scope", "must not run in parallel")
+	reporter.assertPass("the failure is reported to the subtest that created the scope")
+	first.assertPass("the active scope is unaffected")
+}
+
+func TestWithUnexpectedCallStack(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithUnexpectedCallStack())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "b")
+	}, "\n\nStack:\ngoroutine ", "TestWithUnexpectedCallStack")
+	ctrl.Call(subject, "FooMethod", "a")
+}
+
+func TestUnexpectedArgValue_Reason(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.All(gomock.Len(3), gomock.Regex("^a")))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "abcd")
+	}, `Reason: it doesn't match "has length 3"`)
+	ctrl.Call(subject, "FooMethod", "abc")
+}
+
+func TestNamedBecause(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", "a").Named("primary db lookup").Because("cache miss should trigger a load")
+	label := `"primary db lookup" (because cache miss should trigger a load)`
+	if got := call.String(); !strings.HasSuffix(got, label) {
+		t.Errorf("String() = %q, want it to end with %q", got, label)
+	}
+	if got := ctrl.RecordCall(subject, "BarMethod", "b").Because("no label").String(); !strings.HasSuffix(got, " (because no label)") {
+		t.Errorf("String() = %q, want it to end with %q", got, " (because no label)")
+	}
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "b")
+	}, "expected call at ", " "+label+" doesn't match the argument at index 0")
+	reporter.assertFatal(ctrl.Finish)
+	if report := strings.Join(reporter.log, "\n"); !strings.Contains(report, "missing call(s) to *gomock_test.Subject.FooMethod(is equal to a (string)) ") ||
+		!strings.Contains(report, label+" (called 0 of 1 time(s))") {
+		t.Errorf("Finish report = %q, want it to label the missing call", report)
+	}
+}
+
+func TestWithEquality(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithEquality(func(a, b TestStruct) bool {
+		return a.Number == b.Number
+	}))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "a"}, 1)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Not(TestStruct{Number: 1}), 2)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "b"}, 1)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "c"}, 2)
+	})
+}
@@ -0,0 +1,246 @@
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// argMatch is how an argument of an actual call compares to the matcher of
+// an expected call.
+type argMatch struct {
+	index   int
+	got     string
+	want    Matcher
+	matched bool
+}
+
+// argMatches compares args to the matchers of c, splitting them between the
+// matchers the same way matches does. Matchers without an argument are
+// reported as not matched.
+func (c *Call) argMatches(args []any) []argMatch {
+	variadic := c.methodType.IsVariadic()
+	matches := make([]argMatch, 0, len(c.args))
+	for i, m := range c.args {
+		if !variadic || i < c.methodType.NumIn()-1 {
+			if i >= len(args) {
+				matches = append(matches, argMatch{index: i, got: "(missing)", want: m})
+				continue
+			}
+			matches = append(matches, argMatch{i, formatGottenArg(m, args[i]), m, m.Matches(args[i])})
+			continue
+		}
+		if i < len(args) && m.Matches(args[i]) {
+			matches = append(matches, argMatch{i, formatGottenArg(m, args[i]), m, true})
+			continue
+		}
+		var rest []any
+		if i < len(args) {
+			rest = args[i:]
+		}
+		matches = append(matches, argMatch{i, formatGottenArg(m, rest), m, m.Matches(c.variadicArgs(rest))})
+		break
+	}
+	return matches
+}
+
+// callCount describes how many times c has been called against its limit.
+func (c *Call) callCount() string {
+	if c.maxCalls >= 1e8 {
+		return fmt.Sprintf("called %d time(s), no limit", c.numCalls)
+	}
+	return fmt.Sprintf("called %d of at most %d time(s)", c.numCalls, c.maxCalls)
+}
+
+// minCallCount describes how many times c has been called against the
+// number of calls it requires.
+func (c *Call) minCallCount() string {
+	if c.minCalls == c.maxCalls {
+		return fmt.Sprintf("called %d of %d time(s)", c.numCalls, c.minCalls)
+	}
+	return fmt.Sprintf("called %d time(s), want at least %d", c.numCalls, c.minCalls)
+}
+
+// unsatisfiedPreReqs returns the prerequisites of c that have not been
+// satisfied yet.
+func (c *Call) unsatisfiedPreReqs() []*Call {
+	var preReqs []*Call
+	for _, preReq := range c.preReqs {
+		if !preReq.satisfied() {
+			preReqs = append(preReqs, preReq)
+		}
+	}
+	return preReqs
+}
+
+// preReqRule returns the ordering rule that made preReq a prerequisite of
+// c, ready to be appended to a list of details, or an empty string if there
+// is none.
+func (c *Call) preReqRule(preReq *Call) string {
+	if rule, ok := c.orderRules[preReq]; ok {
+		return ", required by " + rule
+	}
+	return ""
+}
+
+// An argMismatchError is returned when an argument doesn't match its
+// matcher. The diff between the argument and the matcher is left out until
+// diff is called, as computing it is wasted on calls that match another
+// expected call.
+type argMismatchError struct {
+	msg     string
+	m       Matcher
+	got     any
+	diffStr string
+}
+
+func (e *argMismatchError) Error() string {
+	return e.msg + e.diffStr
+}
+
+// diff adds the diff between the argument and the matcher to the error.
+func (e *argMismatchError) diff() {
+	e.diffStr = formatDiff(e.m, e.got)
+}
+
+// A candidate is an expected call that didn't match an actual call.
+type candidate struct {
+	call    *Call
+	err     error
+	args    []argMatch
+	matched int
+}
+
+// compare compares args to the matchers of the candidate, argument by
+// argument.
+func (c *candidate) compare(args []any) {
+	var mismatch *argMismatchError
+	if errors.As(c.err, &mismatch) {
+		mismatch.diff()
+	}
+	c.args = c.call.argMatches(args)
+	c.matched = 0
+	for _, a := range c.args {
+		if a.matched {
+			c.matched++
+		}
+	}
+}
+
+// format describes the candidate, with a line per argument if table is set.
+func (c candidate) format(table bool) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%v (%s, %d of %d argument(s) match)\n%v",
+		c.call, c.call.callCount(), c.matched, len(c.call.args), c.err)
+	if table && len(c.args) != 0 {
+		b.WriteString("\nArguments:")
+		for _, a := range c.args {
+			status := "ok"
+			if !a.matched {
+				status = "MISMATCH"
+			}
+			_, _ = fmt.Fprintf(&b, "\n  [%d] %s: got %s, want %v", a.index, status, a.got, a.want)
+		}
+	}
+	if preReqs := c.call.unsatisfiedPreReqs(); len(preReqs) != 0 {
+		b.WriteString("\nUnsatisfied prerequisites:")
+		for _, preReq := range preReqs {
+			_, _ = fmt.Fprintf(&b, "\n  %v (%s%s)", preReq, preReq.callCount(), c.call.preReqRule(preReq))
+		}
+	}
+	return b.String()
+}
+
+// formatCandidates describes why none of the candidates matched a call to
+// method, from the closest one, which matches the most arguments, to the
+// furthest one. Only the closest one gets a line per argument. Candidates
+// that are as close are kept in the order they were tried in, which is
+// newest first if newestFirst is set.
+func formatCandidates(method string, candidates []candidate, newestFirst bool) string {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].matched > candidates[j].matched
+	})
+
+	var b strings.Builder
+	if len(candidates) > 1 {
+		_, _ = fmt.Fprintf(&b, "\n%d expected calls of %q don't match, closest first:", len(candidates), method)
+		if newestFirst {
+			b.WriteString("\n(expected calls are tried newest first)")
+		}
+	}
+	for i, c := range candidates {
+		if len(candidates) > 1 {
+			_, _ = fmt.Fprintf(&b, "\n\n%d. ", i+1)
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString(c.format(i == 0))
+	}
+	return b.String()
+}
+
+// unmatchedCallError is the error of an actual call that matched none of
+// the expected calls. It keeps why each of them rejected the call.
+type unmatchedCallError struct {
+	msg        string
+	candidates []candidate
+}
+
+func (e *unmatchedCallError) Error() string {
+	return e.msg
+}
+
+// rejection returns why call rejected the actual call, or nil if call
+// wasn't considered.
+func (e *unmatchedCallError) rejection(call *Call) error {
+	for _, c := range e.candidates {
+		if c.call == call {
+			return c.err
+		}
+	}
+	return nil
+}
+
+// missingCallReport explains why the expected call is not satisfied: how
+// many times it was called, which prerequisites it is still waiting for and
+// why it rejected the actual calls to its method. ctrl.mu must be held.
+func (ctrl *Controller) missingCallReport(call *Call) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, " (%s)", call.minCallCount())
+	if preReqs := call.unsatisfiedPreReqs(); len(preReqs) != 0 {
+		b.WriteString("\nBlocked by unsatisfied prerequisite(s):")
+		for _, preReq := range preReqs {
+			_, _ = fmt.Fprintf(&b, "\n  %v (%s%s)", preReq, preReq.minCallCount(), call.preReqRule(preReq))
+		}
+	}
+
+	// The calls made in the scopes that ended were checked against
+	// expectations that have since been rolled back.
+	scopes := append([]*Controller{ctrl}, ctrl.nestedScopes()...)
+	var rejected []string
+	for _, inv := range ctrl.root().history {
+		if inv.Err == nil || inv.Receiver != call.receiver || inv.Method != call.method || !slices.Contains(scopes, inv.scope) {
+			continue
+		}
+		var uerr *unmatchedCallError
+		if !errors.As(inv.Err, &uerr) {
+			continue
+		}
+		reason := uerr.rejection(call)
+		if reason == nil {
+			continue
+		}
+		args := make([]string, len(inv.Args))
+		for i, arg := range inv.Args {
+			args[i] = getString(arg)
+		}
+		rejected = append(rejected, fmt.Sprintf("\n  %v(%s) at %s:\n%s",
+			inv.Method, strings.Join(args, ", "), inv.Origin, indent(reason.Error(), "    ")))
+	}
+	if len(rejected) != 0 {
+		_, _ = fmt.Fprintf(&b, "\nRejected call(s) to %v:%s", call.method, strings.Join(rejected, ""))
+	}
+	return b.String()
+}
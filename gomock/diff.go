@@ -0,0 +1,267 @@
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxDiffs is the maximum number of differences reported by diffValues.
+const maxDiffs = 20
+
+// diffValues walks got and want with reflection and returns a line for each
+// difference between them, prefixed with the path of the value that
+// differs, e.g. ".Items[3].Price: got 10, want 12". Unexported fields are
+// compared too. It returns nil if the values are deeply equal.
+func diffValues(got, want any) []string {
+	d := &differ{visited: make(map[visit]bool)}
+	d.diff("", reflect.ValueOf(got), reflect.ValueOf(want))
+	if d.skipped > 0 {
+		d.lines = append(d.lines, fmt.Sprintf("... and %d more difference(s)", d.skipped))
+	}
+	return d.lines
+}
+
+// visit is a pair of pointers, maps or slices compared by a differ, used to
+// stop on cyclic values.
+type visit struct {
+	got, want uintptr
+	typ       reflect.Type
+}
+
+type differ struct {
+	lines   []string
+	skipped int
+	visited map[visit]bool
+}
+
+func (d *differ) report(path string, got, want string) {
+	if len(d.lines) >= maxDiffs {
+		d.skipped++
+		return
+	}
+	if path == "" {
+		path = "(root)"
+	}
+	d.lines = append(d.lines, fmt.Sprintf("%s: got %s, want %s", path, got, want))
+}
+
+func (d *differ) reportValues(path string, got, want reflect.Value) {
+	d.report(path, formatValue(got), formatValue(want))
+}
+
+func (d *differ) diff(path string, got, want reflect.Value) {
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() != want.IsValid() {
+			d.reportValues(path, got, want)
+		}
+		return
+	}
+	if got.Type() != want.Type() {
+		d.report(path, fmt.Sprintf("%s (%v)", formatValue(got), got.Type()), fmt.Sprintf("%s (%v)", formatValue(want), want.Type()))
+		return
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.reportValues(path, got, want)
+			}
+			return
+		}
+		if got.Kind() == reflect.Ptr {
+			if got.Pointer() == want.Pointer() {
+				return
+			}
+			if got.CanInterface() && isGeneratedMock(got.Interface()) {
+				// Mocks are only equal to themselves.
+				d.reportValues(path, got, want)
+				return
+			}
+			if d.seen(got, want) {
+				return
+			}
+		}
+		d.diff(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		if isOpaqueStruct(got.Type()) {
+			// Reporting the internals of types like time.Time isn't
+			// helpful, so print them as a whole.
+			sub := &differ{visited: d.visited}
+			for i := 0; i < got.NumField() && len(sub.lines) == 0; i++ {
+				sub.diff("", got.Field(i), want.Field(i))
+			}
+			if len(sub.lines) != 0 {
+				d.reportValues(path, got, want)
+			}
+			return
+		}
+		for i := 0; i < got.NumField(); i++ {
+			d.diff(path+"."+got.Type().Field(i).Name, got.Field(i), want.Field(i))
+		}
+	case reflect.Slice:
+		if got.IsNil() != want.IsNil() {
+			d.reportValues(path, got, want)
+			return
+		}
+		if got.Len() == want.Len() && got.Pointer() == want.Pointer() {
+			return
+		}
+		if d.seen(got, want) {
+			return
+		}
+		d.diffElems(path, got, want)
+	case reflect.Array:
+		d.diffElems(path, got, want)
+	case reflect.Map:
+		if got.IsNil() != want.IsNil() {
+			d.reportValues(path, got, want)
+			return
+		}
+		if got.Pointer() == want.Pointer() {
+			return
+		}
+		if d.seen(got, want) {
+			return
+		}
+		d.diffMaps(path, got, want)
+	case reflect.Func:
+		// Like reflect.DeepEqual, functions are only equal if both are nil.
+		if !got.IsNil() || !want.IsNil() {
+			d.reportValues(path, got, want)
+		}
+	default:
+		if !leafEqual(got, want) {
+			d.reportValues(path, got, want)
+		}
+	}
+}
+
+// seen records that the pointers, maps or slices got and want are being
+// compared, and returns whether they already were, like reflect.DeepEqual
+// does to stop on cyclic values.
+func (d *differ) seen(got, want reflect.Value) bool {
+	v := visit{got.Pointer(), want.Pointer(), got.Type()}
+	if d.visited[v] {
+		return true
+	}
+	d.visited[v] = true
+	return false
+}
+
+func (d *differ) diffElems(path string, got, want reflect.Value) {
+	n := max(got.Len(), want.Len())
+	for i := 0; i < n; i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= got.Len():
+			d.report(elemPath, "<missing>", formatValue(want.Index(i)))
+		case i >= want.Len():
+			d.report(elemPath, formatValue(got.Index(i)), "<missing>")
+		default:
+			d.diff(elemPath, got.Index(i), want.Index(i))
+		}
+	}
+}
+
+func (d *differ) diffMaps(path string, got, want reflect.Value) {
+	keys := want.MapKeys()
+	for _, k := range got.MapKeys() {
+		if !want.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	// Sort the keys so that the report is stable.
+	sort.Slice(keys, func(i, j int) bool {
+		return formatValue(keys[i]) < formatValue(keys[j])
+	})
+
+	for _, k := range keys {
+		elemPath := fmt.Sprintf("%s[%s]", path, formatValue(k))
+		g, w := got.MapIndex(k), want.MapIndex(k)
+		switch {
+		case !g.IsValid():
+			d.report(elemPath, "<missing>", formatValue(w))
+		case !w.IsValid():
+			d.report(elemPath, formatValue(g), "<missing>")
+		default:
+			d.diff(elemPath, g, w)
+		}
+	}
+}
+
+// isOpaqueStruct returns whether t is a struct without exported fields.
+func isOpaqueStruct(t reflect.Type) bool {
+	if t.NumField() == 0 {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// leafEqual compares two values of the same scalar kind. Unlike
+// reflect.DeepEqual, it doesn't require the values to be interfaceable, so
+// it works on unexported fields.
+func leafEqual(got, want reflect.Value) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == want.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == want.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == want.Uint()
+	case reflect.Float32, reflect.Float64:
+		return got.Float() == want.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == want.Complex()
+	case reflect.String:
+		return got.String() == want.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return got.Pointer() == want.Pointer()
+	default:
+		return false
+	}
+}
+
+// formatValue formats v for a diff line. Strings are quoted so that
+// whitespace differences are visible.
+func formatValue(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return "nil"
+	case v.Kind() == reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.CanInterface() && isGeneratedMock(v.Interface()):
+		return fmt.Sprintf("%T", v.Interface())
+	case v.Kind() == reflect.Interface && !v.IsNil():
+		return formatValue(v.Elem())
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatDiff returns the differences between got and what m wants, ready
+// to be appended to a mismatch message. It returns an empty string if m
+// doesn't implement DiffFormatter or has no difference to report.
+func formatDiff(m Matcher, got any) string {
+	df, ok := m.(DiffFormatter)
+	if !ok {
+		return ""
+	}
+	diff := df.Diff(got)
+	if diff == "" {
+		return ""
+	}
+	return "\nDiff:\n" + indent(diff, "  ")
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
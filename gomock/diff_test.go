@@ -0,0 +1,138 @@
+package gomock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffItem struct {
+	Name  string
+	Price int
+}
+
+type diffOrder struct {
+	ID      int
+	Items   []diffItem
+	Tags    map[string]int
+	Owner   *diffItem
+	Created time.Time
+	note    string
+}
+
+func TestDiffValues(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := diffOrder{
+		ID:      1,
+		Items:   []diffItem{{"a", 1}, {"b", 2}},
+		Tags:    map[string]int{"x": 1, "y": 2},
+		Owner:   &diffItem{"o", 1},
+		Created: now,
+		note:    "n",
+	}
+
+	for _, tt := range []struct {
+		name   string
+		modify func(o *diffOrder)
+		want   []string
+	}{
+		{
+			name:   "equal",
+			modify: func(*diffOrder) {},
+		},
+		{
+			name:   "nested field",
+			modify: func(o *diffOrder) { o.Items = []diffItem{{"a", 1}, {"b", 3}} },
+			want:   []string{".Items[1].Price: got 3, want 2"},
+		},
+		{
+			name:   "extra element",
+			modify: func(o *diffOrder) { o.Items = append(o.Items[:2:2], diffItem{"c", 3}) },
+			want:   []string{`.Items[2]: got {c 3}, want <missing>`},
+		},
+		{
+			name:   "map entries",
+			modify: func(o *diffOrder) { o.Tags = map[string]int{"x": 2, "z": 3} },
+			want: []string{
+				`.Tags["x"]: got 2, want 1`,
+				`.Tags["y"]: got <missing>, want 2`,
+				`.Tags["z"]: got 3, want <missing>`,
+			},
+		},
+		{
+			name:   "through pointer",
+			modify: func(o *diffOrder) { o.Owner = &diffItem{"p", 1} },
+			want:   []string{`.Owner.Name: got "p", want "o"`},
+		},
+		{
+			name:   "nil pointer",
+			modify: func(o *diffOrder) { o.Owner = nil },
+			want:   []string{`.Owner: got <nil>, want &{o 1}`},
+		},
+		{
+			name:   "opaque struct",
+			modify: func(o *diffOrder) { o.Created = now.Add(time.Hour) },
+			want:   []string{".Created: got 2020-01-01 01:00:00 +0000 UTC, want 2020-01-01 00:00:00 +0000 UTC"},
+		},
+		{
+			name:   "unexported field",
+			modify: func(o *diffOrder) { o.note = "m" },
+			want:   []string{`.note: got "m", want "n"`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := want
+			got.Items = append([]diffItem(nil), want.Items...)
+			tt.modify(&got)
+			if diff := diffValues(got, want); !reflect.DeepEqual(diff, tt.want) {
+				t.Errorf("diffValues() = %q, want %q", diff, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffValues_Limit(t *testing.T) {
+	got, want := make([]int, maxDiffs+5), make([]int, maxDiffs+5)
+	for i := range got {
+		got[i] = i
+	}
+	diff := diffValues(got, want)
+	if len(diff) != maxDiffs+1 || diff[maxDiffs] != "... and 4 more difference(s)" {
+		t.Errorf("diffValues() = %q, want %d differences and a summary", diff, maxDiffs)
+	}
+}
+
+func TestDiffValues_Cycle(t *testing.T) {
+	type node struct {
+		Next *node
+		V    int
+	}
+	a, b := &node{V: 1}, &node{V: 2}
+	a.Next, b.Next = a, b
+	if diff := diffValues(a, b); !reflect.DeepEqual(diff, []string{".V: got 1, want 2"}) {
+		t.Errorf("diffValues() = %q", diff)
+	}
+}
+
+func TestDiffValues_CyclicMapsAndSlices(t *testing.T) {
+	a, b := map[string]any{"v": 1}, map[string]any{"v": 2}
+	a["self"], b["self"] = a, b
+	if diff := diffValues(a, b); !reflect.DeepEqual(diff, []string{`["v"]: got 1, want 2`}) {
+		t.Errorf("diffValues() = %q", diff)
+	}
+
+	s, u := []any{1, nil}, []any{2, nil}
+	s[1], u[1] = s, u
+	if diff := diffValues(s, u); !reflect.DeepEqual(diff, []string{"[0]: got 1, want 2"}) {
+		t.Errorf("diffValues() = %q", diff)
+	}
+}
+
+func TestFormatDiff_Nested(t *testing.T) {
+	m := All(Not(Nil()), Eq(diffItem{"a", 1}))
+	got := formatDiff(m, diffItem{"a", 2})
+	want := "\nDiff:\n  is equal to {a 1} (gomock.diffItem):\n    .Price: got 2, want 1"
+	if got != want {
+		t.Errorf("formatDiff() = %q, want %q", got, want)
+	}
+}
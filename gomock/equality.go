@@ -0,0 +1,97 @@
+package gomock
+
+import "reflect"
+
+// equality is a function that tells whether two values of typ are equal,
+// as registered with WithEquality.
+type equality struct {
+	typ   reflect.Type
+	equal func(a, b any) bool
+}
+
+// equalities are the equality functions registered on a Controller, in
+// registration order, with at most one per type.
+type equalities []equality
+
+// lookup returns the function registered for values of the types a and b:
+// the one of their type if they have the same, or else the first
+// registered for an interface that both implement.
+func (eqs equalities) lookup(a, b reflect.Type) (func(a, b any) bool, bool) {
+	if a == b {
+		for _, e := range eqs {
+			if e.typ == a {
+				return e.equal, true
+			}
+		}
+	}
+	for _, e := range eqs {
+		if e.typ.Kind() == reflect.Interface && a.Implements(e.typ) && b.Implements(e.typ) {
+			return e.equal, true
+		}
+	}
+	return nil, false
+}
+
+// equalityBinder is implemented by matchers that compare values for
+// equality, directly or through their inner matchers, so that they can use
+// the equalities registered on the Controller.
+type equalityBinder interface {
+	withEqualities(eqs equalities) Matcher
+}
+
+// bindEqualities returns m using eqs to compare values, if m supports it.
+func bindEqualities(m Matcher, eqs equalities) Matcher {
+	if b, ok := m.(equalityBinder); ok && len(eqs) != 0 {
+		return b.withEqualities(eqs)
+	}
+	return m
+}
+
+// bindAllEqualities is like bindEqualities for each of ms.
+func bindAllEqualities(ms []Matcher, eqs equalities) []Matcher {
+	bound := make([]Matcher, len(ms))
+	for i, m := range ms {
+		bound[i] = bindEqualities(m, eqs)
+	}
+	return bound
+}
+
+// equal reports whether a and b are equal. It uses the function registered
+// for their types in eqs, then their own Equal method if they have one, and
+// reflect.DeepEqual otherwise. Values of different types are only equal
+// through a function registered for an interface.
+func equal(a, b any, eqs equalities) bool {
+	if a == nil || b == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	t := reflect.TypeOf(a)
+	if fn, ok := eqs.lookup(t, reflect.TypeOf(b)); ok {
+		return fn(a, b)
+	}
+	if t != reflect.TypeOf(b) {
+		return false
+	}
+	if fn, ok := equalMethod(t); ok {
+		av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+		// Don't risk calling the method on a nil receiver.
+		if t.Kind() != reflect.Ptr || !av.IsNil() && !bv.IsNil() {
+			return fn.Func.Call([]reflect.Value{av, bv})[0].Bool()
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// equalMethod returns the method of t with the signature Equal(t) bool, if
+// t has one. time.Time is a notable example.
+func equalMethod(t reflect.Type) (reflect.Method, bool) {
+	m, ok := t.MethodByName("Equal")
+	if !ok {
+		return reflect.Method{}, false
+	}
+	// The receiver is the first argument of m.Type.
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != t || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
@@ -0,0 +1,88 @@
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type equalByID struct {
+	ID   int
+	Name string
+}
+
+func (e equalByID) Equal(other equalByID) bool {
+	return e.ID == other.ID
+}
+
+type equalPtr struct{ ID int }
+
+func (e *equalPtr) Equal(other *equalPtr) bool {
+	return e.ID == other.ID
+}
+
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	caseInsensitive := equalities{{
+		typ: reflect.TypeOf(""),
+		equal: func(a, b any) bool {
+			return strings.EqualFold(a.(string), b.(string))
+		},
+	}}
+	sameMessage := equalities{{
+		typ: reflect.TypeFor[error](),
+		equal: func(a, b any) bool {
+			return a.(error).Error() == b.(error).Error()
+		},
+	}}
+
+	for _, tt := range []struct {
+		name string
+		a, b any
+		eqs  equalities
+		want bool
+	}{
+		{"deep equal", []int{1}, []int{1}, nil, true},
+		{"time with monotonic reading", now, now.Round(0), nil, true},
+		{"Equal method", equalByID{1, "a"}, equalByID{1, "b"}, nil, true},
+		{"Equal method mismatch", equalByID{1, "a"}, equalByID{2, "a"}, nil, false},
+		{"Equal method on pointer", &equalPtr{1}, &equalPtr{1}, nil, true},
+		{"nil pointer", (*equalPtr)(nil), &equalPtr{1}, nil, false},
+		{"registered", "ABC", "abc", caseInsensitive, true},
+		{"not registered", "ABC", "abc", nil, false},
+		{"nil", nil, nil, nil, true},
+		{"nil and Equal method", nil, equalByID{1, "a"}, nil, false},
+		{"Equal method and nil", equalByID{1, "a"}, nil, nil, false},
+		{"nil and registered", nil, "abc", caseInsensitive, false},
+		{"different types", equalByID{1, "a"}, &equalPtr{1}, nil, false},
+		{"registered interface", errors.New("a"), fmt.Errorf("a"), sameMessage, true},
+		{"registered interface mismatch", errors.New("a"), errors.New("b"), sameMessage, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equal(tt.a, tt.b, tt.eqs); got != tt.want {
+				t.Errorf("equal(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+type stringerError string
+
+func (e stringerError) Error() string  { return string(e) }
+func (e stringerError) String() string { return string(e) }
+
+func TestEqual_InterfacesInRegistrationOrder(t *testing.T) {
+	eqs := equalities{
+		{typ: reflect.TypeFor[fmt.Stringer](), equal: func(a, b any) bool { return true }},
+		{typ: reflect.TypeFor[error](), equal: func(a, b any) bool { return false }},
+	}
+	// Ranging over a map would pick either function at random.
+	for i := 0; i < 100; i++ {
+		if !equal(stringerError("a"), stringerError("b"), eqs) {
+			t.Fatal("equal should use the function of the interface registered first")
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type errorMatcher struct {
+	desc string
+	fn   func(err error) bool // nil to match nil errors only
+}
+
+func (e errorMatcher) Matches(x any) bool {
+	if e.fn == nil {
+		return x == nil
+	}
+	err, ok := x.(error)
+	return ok && err != nil && e.fn(err)
+}
+
+func (e errorMatcher) Got(got any) string {
+	return formatGottenError(got)
+}
+
+func (e errorMatcher) String() string {
+	return e.desc
+}
+
+// formatGottenError formats an argument expected to be an error, showing
+// its message.
+func formatGottenError(got any) string {
+	switch err := got.(type) {
+	case nil:
+		return "nil error"
+	case error:
+		return fmt.Sprintf("error %q (%T)", err.Error(), err)
+	default:
+		return fmt.Sprintf("%v (%T), which is not an error", got, got)
+	}
+}
+
+// ErrorIs returns a matcher that matches errors for which
+// errors.Is(err, target) is true, i.e. errors that are or wrap target. Like
+// errors.Is, ErrorIs(nil) only matches nil errors.
+//
+// Example usage:
+//
+//	ErrorIs(io.EOF).Matches(fmt.Errorf("read: %w", io.EOF)) // returns true
+//	ErrorIs(io.EOF).Matches(errors.New("EOF")) // returns false
+func ErrorIs(target error) Matcher {
+	if target == nil {
+		return errorMatcher{desc: "is a nil error"}
+	}
+	return errorMatcher{
+		desc: fmt.Sprintf("is an error wrapping %q (%T)", target.Error(), target),
+		fn:   func(err error) bool { return errors.Is(err, target) },
+	}
+}
+
+// ErrorAs returns a matcher that matches errors for which errors.As finds
+// an error of type T in the chain. If matchers are given, the error found
+// must also be matched by all of them.
+//
+// Example usage:
+//
+//	ErrorAs[*fs.PathError]().Matches(fmt.Errorf("open: %w", &fs.PathError{})) // returns true
+//	ErrorAs[*fs.PathError](Field("Op", "open")).Matches(&fs.PathError{Op: "read"}) // returns false
+func ErrorAs[T error](ms ...Matcher) Matcher {
+	desc := fmt.Sprintf("is an error wrapping a %v", reflect.TypeFor[T]())
+	if len(ms) != 0 {
+		desc += " that " + allMatcher{ms}.String()
+	}
+	return errorMatcher{
+		desc: desc,
+		fn: func(err error) bool {
+			var target T
+			if !errors.As(err, &target) {
+				return false
+			}
+			for _, m := range ms {
+				if !m.Matches(target) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// ErrorContains returns a matcher that matches errors whose message
+// contains substr.
+//
+// Example usage:
+//
+//	ErrorContains("timeout").Matches(errors.New("dial: timeout")) // returns true
+//	ErrorContains("timeout").Matches(nil) // returns false
+func ErrorContains(substr string) Matcher {
+	return errorMatcher{
+		desc: fmt.Sprintf("is an error whose message contains %q", substr),
+		fn:   func(err error) bool { return strings.Contains(err.Error(), substr) },
+	}
+}
@@ -0,0 +1,24 @@
+package gomock_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestErrorMatchers_Messages(t *testing.T) {
+	assertEqual(t, `is an error wrapping "EOF" (*errors.errorString)`, gomock.ErrorIs(io.EOF).String())
+	assertEqual(t, "is a nil error", gomock.ErrorIs(nil).String())
+	assertEqual(t, "is an error wrapping a *fs.PathError", gomock.ErrorAs[*fs.PathError]().String())
+	assertEqual(t, "is an error wrapping a *fs.PathError that has field Op that is equal to open (string)",
+		gomock.ErrorAs[*fs.PathError](gomock.Field("Op", "open")).String())
+	assertEqual(t, `is an error whose message contains "timeout"`, gomock.ErrorContains("timeout").String())
+
+	got := gomock.ErrorContains("timeout").(gomock.GotFormatter)
+	assertEqual(t, `error "refused" (*errors.errorString)`, got.Got(errors.New("refused")))
+	assertEqual(t, "nil error", got.Got(nil))
+	assertEqual(t, "1 (int), which is not an error", got.Got(1))
+}
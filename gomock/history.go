@@ -0,0 +1,119 @@
+package gomock
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// An Invocation records a single call that was made to a mock through
+// Controller.Call, whether or not it matched an expected call.
+type Invocation struct {
+	// Receiver is the mock the method was called on.
+	Receiver any
+	// Method is the name of the method that was called.
+	Method string
+	// Args are the actual arguments of the call. The slice is a copy of the
+	// one the mock was called with.
+	Args []any
+	// Rets are the values returned to the caller. A call that did not match
+	// an expected call holds the zero values it returned under
+	// WarnOnUnexpectedCall or IgnoreUnexpectedCall, and nil under
+	// FailOnUnexpectedCall.
+	Rets []any
+	// Expected is the expected call the invocation matched, or nil if it
+	// did not match any.
+	Expected *Call
+	// Err explains why the invocation did not match any expected call. It
+	// is nil if the invocation matched.
+	Err error
+	// Goroutine is the ID of the goroutine that made the call. It is 0
+	// unless the Controller was created with WithGoroutineIDs.
+	Goroutine uint64
+	// Origin is the file:line the mock was called from.
+	Origin string
+
+	// scope is the Controller whose expectations were searched first: the
+	// innermost active scope at the time of the call, or the root
+	// Controller.
+	scope *Controller
+}
+
+// Matched returns whether the invocation matched an expected call.
+func (inv Invocation) Matched() bool {
+	return inv.Expected != nil
+}
+
+func (inv Invocation) String() string {
+	args := make([]string, len(inv.Args))
+	for i, arg := range inv.Args {
+		args[i] = getString(arg)
+	}
+	s := fmt.Sprintf("%T.%v(%s) at %s", inv.Receiver, inv.Method, strings.Join(args, ", "), inv.Origin)
+	if inv.Goroutine != 0 {
+		s += fmt.Sprintf(" on goroutine %d", inv.Goroutine)
+	}
+	if inv.Err != nil {
+		s += " (unexpected)"
+	}
+	return s
+}
+
+// record adds inv to the history of the root Controller ctrl, dropping the
+// oldest invocation if the history is full. ctrl.mu must be held.
+func (ctrl *Controller) record(inv *Invocation) {
+	if ctrl.historyLimit > 0 && len(ctrl.history) == ctrl.historyLimit {
+		ctrl.history[0] = nil
+		ctrl.history = ctrl.history[1:]
+	}
+	ctrl.history = append(ctrl.history, inv)
+}
+
+// History returns every invocation made to the mocks bound to this
+// Controller, in the order they were made, or only the most recent ones if
+// the Controller was created with WithHistoryLimit.
+func (ctrl *Controller) History() []Invocation {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	root := ctrl.root()
+	history := make([]Invocation, len(root.history))
+	for i, inv := range root.history {
+		history[i] = *inv
+	}
+	return history
+}
+
+// CallsTo returns the invocations of method on receiver, in the order they
+// were made. receiver is the mock itself, not its recorder.
+func (ctrl *Controller) CallsTo(receiver any, method string) []Invocation {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	var calls []Invocation
+	for _, inv := range ctrl.root().history {
+		if inv.Receiver == receiver && inv.Method == method {
+			calls = append(calls, *inv)
+		}
+	}
+	return calls
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from the
+// header of its stack trace. It returns 0 if the ID cannot be determined.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// The header has the form "goroutine 42 [running]:".
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, err := strconv.ParseUint(string(buf), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
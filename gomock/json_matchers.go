@@ -0,0 +1,289 @@
+package gomock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type jsonEqMatcher struct {
+	want any
+	// ignore are the segments of each of ignorePaths.
+	ignore      [][]string
+	ignorePaths []string
+}
+
+func (j jsonEqMatcher) Matches(x any) bool {
+	got, err := decodeJSONArg(x)
+	if err != nil {
+		return false
+	}
+	return len(j.diff(got)) == 0
+}
+
+func (j jsonEqMatcher) Got(got any) string {
+	v, err := decodeJSONArg(got)
+	if err != nil {
+		return fmt.Sprintf("%v (%T), which %v", got, got, err)
+	}
+	diff := j.diff(v)
+	if len(diff) == 0 {
+		return fmt.Sprintf("%s (%T)", formatJSON(v), got)
+	}
+	return fmt.Sprintf("%s (%T), which differs at:\n%s", formatJSON(v), got, indent(strings.Join(diff, "\n"), "  "))
+}
+
+func (j jsonEqMatcher) String() string {
+	s := "is JSON equivalent to " + formatJSON(j.want)
+	if len(j.ignorePaths) != 0 {
+		s += " ignoring " + strings.Join(j.ignorePaths, ", ")
+	}
+	return s
+}
+
+// diff returns a line for each difference between got and the expected
+// JSON, prefixed with its path, e.g. "$.items[0].id: got 1, want 2".
+func (j jsonEqMatcher) diff(got any) []string {
+	var lines []string
+	j.compare(nil, got, j.want, &lines)
+	return lines
+}
+
+func (j jsonEqMatcher) compare(path []string, got, want any, lines *[]string) {
+	if j.ignored(path) {
+		return
+	}
+	report := func(g, w string) {
+		*lines = append(*lines, fmt.Sprintf("%s: got %s, want %s", jsonPath(path), g, w))
+	}
+
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			report(formatJSON(got), formatJSON(want))
+			return
+		}
+		keys := make([]string, 0, len(w)+len(g))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := append(path[:len(path):len(path)], "."+k)
+			gv, gok := g[k]
+			wv, wok := w[k]
+			switch {
+			case j.ignored(p):
+			case !gok:
+				*lines = append(*lines, fmt.Sprintf("%s: got <missing>, want %s", jsonPath(p), formatJSON(wv)))
+			case !wok:
+				*lines = append(*lines, fmt.Sprintf("%s: got %s, want <missing>", jsonPath(p), formatJSON(gv)))
+			default:
+				j.compare(p, gv, wv, lines)
+			}
+		}
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			report(formatJSON(got), formatJSON(want))
+			return
+		}
+		for i := 0; i < max(len(g), len(w)); i++ {
+			p := append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i))
+			switch {
+			case j.ignored(p):
+			case i >= len(g):
+				*lines = append(*lines, fmt.Sprintf("%s: got <missing>, want %s", jsonPath(p), formatJSON(w[i])))
+			case i >= len(w):
+				*lines = append(*lines, fmt.Sprintf("%s: got %s, want <missing>", jsonPath(p), formatJSON(g[i])))
+			default:
+				j.compare(p, g[i], w[i], lines)
+			}
+		}
+	case json.Number:
+		if g, ok := got.(json.Number); !ok || !jsonNumbersEqual(g, w) {
+			report(formatJSON(got), formatJSON(want))
+		}
+	default:
+		// Strings, booleans and null.
+		if got != want {
+			report(formatJSON(got), formatJSON(want))
+		}
+	}
+}
+
+// ignored returns whether path matches one of the ignored paths.
+func (j jsonEqMatcher) ignored(path []string) bool {
+	for _, ign := range j.ignore {
+		if len(ign) != len(path) {
+			continue
+		}
+		matches := true
+		for i, seg := range ign {
+			if seg == path[i] || (seg == ".*" && path[i][0] == '.') || (seg == "[*]" && path[i][0] == '[') {
+				continue
+			}
+			matches = false
+			break
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonPath formats path segments as a JSON path.
+func jsonPath(path []string) string {
+	return "$" + strings.Join(path, "")
+}
+
+// parseJSONPath splits a JSON path like "$.items[0].id" into segments like
+// ".items", "[0]" and ".id". Both ".*" and "[*]" are wildcards.
+func parseJSONPath(path string) ([]string, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("gomock: JSON path %q must start with $", path)
+	}
+	var segs []string
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("gomock: JSON path %q has an empty key", path)
+			}
+			segs, rest = append(segs, rest[:end+1]), rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("gomock: JSON path %q has an unterminated index", path)
+			}
+			if idx := rest[1:end]; idx != "*" {
+				if _, err := strconv.Atoi(idx); err != nil {
+					return nil, fmt.Errorf("gomock: JSON path %q has an invalid index %q", path, idx)
+				}
+			}
+			segs, rest = append(segs, rest[:end+1]), rest[end+1:]
+		default:
+			return nil, fmt.Errorf("gomock: JSON path %q is invalid at %q", path, rest)
+		}
+	}
+	return segs, nil
+}
+
+// jsonNumbersEqual compares JSON numbers by value, so that 1 and 1.0 are
+// equal.
+func jsonNumbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	if ai, err := a.Int64(); err == nil {
+		if bi, err := b.Int64(); err == nil {
+			return ai == bi
+		}
+	}
+	af, aerr := a.Float64()
+	bf, berr := b.Float64()
+	return aerr == nil && berr == nil && af == bf
+}
+
+// decodeJSONArg decodes an argument holding JSON, which must be a string,
+// a byte slice or a json.RawMessage.
+func decodeJSONArg(x any) (any, error) {
+	data, ok := jsonBytes(x)
+	if !ok {
+		return nil, fmt.Errorf("is not a string or a byte slice")
+	}
+	return decodeJSON(data)
+}
+
+func decodeJSON(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("is not valid JSON: %v", err)
+	}
+	if d.More() {
+		return nil, fmt.Errorf("is not valid JSON: unexpected data after the value")
+	}
+	return v, nil
+}
+
+// formatJSON formats a decoded JSON value compactly.
+func formatJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// JSONEq returns a matcher that matches strings, byte slices and
+// json.RawMessage values holding JSON that is semantically equal to
+// expected: the order of object keys, whitespace and the formatting of
+// numbers don't matter. expected may itself be JSON in a string, a byte
+// slice or a json.RawMessage, or any other value, which is marshaled to
+// JSON.
+//
+// Values at ignorePaths are left out of the comparison. Paths start with $,
+// followed by object keys like .name and array indices like [0]; .* and
+// [*] match any key or index, e.g. "$.items[*].id".
+//
+// JSONEq panics if expected can't be converted to JSON, or if a path is
+// invalid.
+//
+// Example usage:
+//
+//	JSONEq(`{"a": 1, "b": [true]}`).Matches(`{"b":[true],"a":1.0}`) // returns true
+//	JSONEq(`{"id": 1, "name": "x"}`, "$.id").Matches([]byte(`{"id": 2, "name": "x"}`)) // returns true
+//	JSONEq(map[string]int{"a": 1}).Matches(`{"a": 2}`) // returns false
+func JSONEq(expected any, ignorePaths ...string) Matcher {
+	data, ok := jsonBytes(expected)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(expected); err != nil {
+			panic(fmt.Sprintf("gomock: JSONEq can't marshal %T: %v", expected, err))
+		}
+	}
+	want, err := decodeJSON(data)
+	if err != nil {
+		panic(fmt.Sprintf("gomock: JSONEq expected value %s", err))
+	}
+
+	ignore := make([][]string, len(ignorePaths))
+	for i, p := range ignorePaths {
+		if ignore[i], err = parseJSONPath(p); err != nil {
+			panic(err.Error())
+		}
+	}
+	return jsonEqMatcher{want: want, ignore: ignore, ignorePaths: ignorePaths}
+}
+
+// jsonBytes returns the bytes of x if it is a string or a byte slice,
+// assumed to hold JSON.
+func jsonBytes(x any) ([]byte, bool) {
+	v := reflect.ValueOf(x)
+	switch {
+	case v.Kind() == reflect.String:
+		return []byte(v.String()), true
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return v.Bytes(), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,45 @@
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestJSONEqMatcher_Got(t *testing.T) {
+	m := gomock.JSONEq(`{"a": 1, "b": {"c": [1, 2]}, "d": "x"}`, "$.d")
+	got := m.(gomock.GotFormatter).Got(`{"a": 2, "b": {"c": [1]}, "e": null}`)
+	want := `{"a":2,"b":{"c":[1]},"e":null} (string), which differs at:
+  $.a: got 2, want 1
+  $.b.c[1]: got <missing>, want 2
+  $.e: got null, want <missing>`
+	if got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+
+	got = m.(gomock.GotFormatter).Got("{")
+	want = "{ (string), which is not valid JSON: unexpected EOF"
+	if got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+
+	assertEqual(t, `is JSON equivalent to {"a":1,"b":{"c":[1,2]},"d":"x"} ignoring $.d`, m.String())
+}
+
+func TestJSONEqMatcher_InvalidArgs(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"invalid expected JSON": func() { gomock.JSONEq(`{`) },
+		"unmarshalable value":   func() { gomock.JSONEq(func() {}) },
+		"invalid path":          func() { gomock.JSONEq(`{}`, "meta.id") },
+		"invalid index":         func() { gomock.JSONEq(`{}`, "$.items[x]") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("JSONEq should panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
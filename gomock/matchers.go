@@ -77,6 +77,47 @@ func (f GotFormatterFunc) Got(got any) string {
 	return f(got)
 }
 
+// DiffFormatter is used to better print failure messages. If a matcher
+// implements DiffFormatter, the failure message also prints the
+// differences it reports, after the "Got" and "Want" lines.
+type DiffFormatter interface {
+	// Diff is invoked with the received value and describes how it differs
+	// from what the matcher wants, one difference per line. It returns an
+	// empty string if there is nothing useful to report.
+	Diff(got any) string
+}
+
+// ExplainingMatcher is a Matcher that can explain why a value doesn't
+// match. If a matcher implements ExplainingMatcher, the failure message
+// includes its explanation.
+type ExplainingMatcher interface {
+	Matcher
+
+	// MatchExplain returns whether x is a match and, if it isn't, the
+	// reason why. The reason may be empty if there is nothing to add to
+	// the matcher's description.
+	MatchExplain(x any) (bool, string)
+}
+
+// explain returns whether x matches m, and the reason why it doesn't if m
+// is an ExplainingMatcher.
+func explain(m Matcher, x any) (bool, string) {
+	if em, ok := m.(ExplainingMatcher); ok {
+		return em.MatchExplain(x)
+	}
+	return m.Matches(x), ""
+}
+
+// explainFailure describes why x doesn't match m, quoting m's description
+// and adding its explanation, if any.
+func explainFailure(m Matcher, x any) string {
+	s := fmt.Sprintf("%q", m)
+	if _, reason := explain(m, x); reason != "" {
+		s += ": " + reason
+	}
+	return s
+}
+
 // GotFormatterAdapter attaches a GotFormatter to a Matcher.
 func GotFormatterAdapter(s GotFormatter, m Matcher) Matcher {
 	return struct {
@@ -114,8 +155,33 @@ func (c condMatcher[T]) String() string {
 	return "adheres to a custom condition"
 }
 
+type checkMatcher[T any] struct {
+	fn func(x T) error
+}
+
+func (c checkMatcher[T]) Matches(x any) bool {
+	ok, _ := c.MatchExplain(x)
+	return ok
+}
+
+func (c checkMatcher[T]) MatchExplain(x any) (bool, string) {
+	typed, ok := x.(T)
+	if !ok {
+		return false, fmt.Sprintf("%T is not a %v", x, reflect.TypeFor[T]())
+	}
+	if err := c.fn(typed); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+func (c checkMatcher[T]) String() string {
+	return "passes a custom check"
+}
+
 type eqMatcher struct {
-	x any
+	x   any
+	eqs equalities
 }
 
 func (e eqMatcher) Matches(x any) bool {
@@ -130,12 +196,34 @@ func (e eqMatcher) Matches(x any) bool {
 
 	if x1Val.Type().AssignableTo(x2Val.Type()) {
 		x1ValConverted := x1Val.Convert(x2Val.Type())
-		return reflect.DeepEqual(x1ValConverted.Interface(), x2Val.Interface())
+		return equal(x1ValConverted.Interface(), x2Val.Interface(), e.eqs)
 	}
 
 	return false
 }
 
+func (e eqMatcher) withEqualities(eqs equalities) Matcher {
+	return eqMatcher{x: e.x, eqs: eqs}
+}
+
+func (e eqMatcher) Diff(got any) string {
+	if e.x == nil || got == nil {
+		return ""
+	}
+	want := reflect.ValueOf(e.x)
+	if !want.Type().AssignableTo(reflect.TypeOf(got)) {
+		return ""
+	}
+	want = want.Convert(reflect.TypeOf(got))
+	switch want.Kind() {
+	case reflect.Array, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.Struct:
+		return strings.Join(diffValues(got, want.Interface()), "\n")
+	default:
+		// The Got and Want lines already tell everything about scalars.
+		return ""
+	}
+}
+
 func (e eqMatcher) String() string {
 	return fmt.Sprintf("is equal to %s (%T)", getString(e.x), e.x)
 }
@@ -169,6 +257,17 @@ func (n notMatcher) Matches(x any) bool {
 	return !n.m.Matches(x)
 }
 
+func (n notMatcher) MatchExplain(x any) (bool, string) {
+	if n.m.Matches(x) {
+		return false, fmt.Sprintf("it matches %q", n.m)
+	}
+	return true, ""
+}
+
+func (n notMatcher) withEqualities(eqs equalities) Matcher {
+	return notMatcher{bindEqualities(n.m, eqs)}
+}
+
 func (n notMatcher) String() string {
 	return "not(" + n.m.String() + ")"
 }
@@ -217,6 +316,25 @@ func (am anyOfMatcher) Matches(x any) bool {
 	return false
 }
 
+func (am anyOfMatcher) MatchExplain(x any) (bool, string) {
+	reasons := make([]string, 0, len(am.matchers))
+	for _, m := range am.matchers {
+		if m.Matches(x) {
+			return true, ""
+		}
+		reasons = append(reasons, explainFailure(m, x))
+	}
+	return false, "it matches none of " + strings.Join(reasons, "; ")
+}
+
+func (am anyOfMatcher) withEqualities(eqs equalities) Matcher {
+	return anyOfMatcher{bindAllEqualities(am.matchers, eqs)}
+}
+
+func (am anyOfMatcher) Diff(got any) string {
+	return diffInner(am.matchers, got)
+}
+
 func (am anyOfMatcher) String() string {
 	ss := make([]string, 0, len(am.matchers))
 	for _, matcher := range am.matchers {
@@ -238,6 +356,23 @@ func (am allMatcher) Matches(x any) bool {
 	return true
 }
 
+func (am allMatcher) MatchExplain(x any) (bool, string) {
+	for _, m := range am.matchers {
+		if !m.Matches(x) {
+			return false, "it doesn't match " + explainFailure(m, x)
+		}
+	}
+	return true, ""
+}
+
+func (am allMatcher) withEqualities(eqs equalities) Matcher {
+	return allMatcher{bindAllEqualities(am.matchers, eqs)}
+}
+
+func (am allMatcher) Diff(got any) string {
+	return diffInner(am.matchers, got)
+}
+
 func (am allMatcher) String() string {
 	ss := make([]string, 0, len(am.matchers))
 	for _, matcher := range am.matchers {
@@ -246,6 +381,23 @@ func (am allMatcher) String() string {
 	return strings.Join(ss, "; ")
 }
 
+// diffInner collects the differences reported by the matchers among ms
+// that don't match got, each indented under the description of its
+// matcher.
+func diffInner(ms []Matcher, got any) string {
+	var diffs []string
+	for _, m := range ms {
+		df, ok := m.(DiffFormatter)
+		if !ok || m.Matches(got) {
+			continue
+		}
+		if diff := df.Diff(got); diff != "" {
+			diffs = append(diffs, m.String()+":\n"+indent(diff, "  "))
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
+
 type lenMatcher struct {
 	i int
 }
@@ -265,7 +417,8 @@ func (m lenMatcher) String() string {
 }
 
 type inAnyOrderMatcher struct {
-	x any
+	x   any
+	eqs equalities
 }
 
 func (m inAnyOrderMatcher) Matches(x any) bool {
@@ -285,7 +438,7 @@ func (m inAnyOrderMatcher) Matches(x any) bool {
 	usedFromGiven := make([]bool, given.Len())
 	foundFromWanted := make([]bool, wanted.Len())
 	for i := 0; i < wanted.Len(); i++ {
-		wantedMatcher := Eq(wanted.Index(i).Interface())
+		wantedMatcher := eqMatcher{x: wanted.Index(i).Interface(), eqs: m.eqs}
 		for j := 0; j < given.Len(); j++ {
 			if usedFromGiven[j] {
 				continue
@@ -314,6 +467,10 @@ func (m inAnyOrderMatcher) Matches(x any) bool {
 	return extraInGiven == 0 && missingFromWanted == 0
 }
 
+func (m inAnyOrderMatcher) withEqualities(eqs equalities) Matcher {
+	return inAnyOrderMatcher{x: m.x, eqs: eqs}
+}
+
 func (m inAnyOrderMatcher) prepareValue(x any) (reflect.Value, bool) {
 	xValue := reflect.ValueOf(x)
 	switch xValue.Kind() {
@@ -328,6 +485,20 @@ func (m inAnyOrderMatcher) String() string {
 	return fmt.Sprintf("has the same elements as %v", m.x)
 }
 
+// toMatcher returns x if it is a Matcher, and a matcher that matches values
+// equal to x otherwise.
+func toMatcher(x any) Matcher {
+	if m, ok := x.(Matcher); ok {
+		return m
+	}
+	if x == nil {
+		// Handle nil specially so that passing a nil interface value
+		// will match the typed nils of concrete args.
+		return Nil()
+	}
+	return Eq(x)
+}
+
 // Constructors
 
 // All returns a composite Matcher that returns true if and only all of the
@@ -347,6 +518,20 @@ func Any() Matcher { return anyMatcher{} }
 //	Cond(func(x int){return x == 2}).Matches(1) // returns false
 func Cond[T any](fn func(x T) bool) Matcher { return condMatcher[T]{fn} }
 
+// Check returns a matcher that matches when the given function returns a nil
+// error after passing it the parameter to the mock function. Unlike Cond,
+// the error explains the mismatch in the failure message.
+//
+// Example usage:
+//
+//	Check(func(x int) error {
+//	    if x%2 != 0 {
+//	        return fmt.Errorf("%d is odd", x)
+//	    }
+//	    return nil
+//	}).Matches(2) // returns true
+func Check[T any](fn func(x T) error) Matcher { return checkMatcher[T]{fn} }
+
 // AnyOf returns a composite Matcher that returns true if at least one of the
 // matchers returns true.
 //
@@ -369,13 +554,16 @@ func AnyOf(xs ...any) Matcher {
 	return anyOfMatcher{ms}
 }
 
-// Eq returns a matcher that matches on equality.
+// Eq returns a matcher that matches on equality. Values are compared with
+// the function registered for their type with WithEquality, if any, then
+// with their own Equal method if they have one, e.g. time.Time.Equal, and
+// with reflect.DeepEqual otherwise.
 //
 // Example usage:
 //
 //	Eq(5).Matches(5) // returns true
 //	Eq(5).Matches(4) // returns false
-func Eq(x any) Matcher { return eqMatcher{x} }
+func Eq(x any) Matcher { return eqMatcher{x: x} }
 
 // Len returns a matcher that matches on length. This matcher returns false if
 // is compared to a type that is not an array, chan, map, slice, or string.
@@ -443,5 +631,5 @@ func AssignableToTypeOf(x any) Matcher {
 //	InAnyOrder([]int{1, 2, 3}).Matches([]int{1, 3, 2}) // returns true
 //	InAnyOrder([]int{1, 2, 3}).Matches([]int{1, 2}) // returns false
 func InAnyOrder(x any) Matcher {
-	return inAnyOrderMatcher{x}
+	return inAnyOrderMatcher{x: x}
 }
@@ -18,9 +18,14 @@ package gomock_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"reflect"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 	"go.uber.org/mock/gomock/internal/mock_gomock"
@@ -31,10 +36,19 @@ type (
 	B struct {
 		Name string
 	}
+	age int
 )
 
 func TestMatchers(t *testing.T) {
 	type e any
+	short := gomock.CondT(func(s string) bool { return len(s) < 4 })
+	bg := context.Background()
+	withValue := context.WithValue(bg, valueKey("user"), "alice")
+	withDeadline, cancel := context.WithTimeout(withValue, time.Hour)
+	defer cancel()
+	canceled, cancel := context.WithCancel(bg)
+	cancel()
+	pathErr := &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}
 	tests := []struct {
 		name    string
 		matcher gomock.Matcher
@@ -68,6 +82,137 @@ func TestMatchers(t *testing.T) {
 		{"test Cond", gomock.Cond(func(x B) bool { return x.Name == "Dam" }), []e{B{Name: "Dam"}}, []e{B{Name: "Dave"}}},
 		{"test Cond wrong type", gomock.Cond(func(x B) bool { return x.Name == "Dam" }), []e{B{Name: "Dam"}}, []e{"Dave"}},
 		{"test Cond any type", gomock.Cond(func(x any) bool { return x.(B).Name == "Dam" }), []e{B{Name: "Dam"}}, []e{B{Name: "Dave"}}},
+		{
+			"test Check", gomock.Check(func(x B) error {
+				if x.Name != "Dam" {
+					return errors.New("not Dam")
+				}
+				return nil
+			}),
+			[]e{B{Name: "Dam"}}, []e{B{Name: "Dave"}, "Dam"},
+		},
+		{
+			"test Contains", gomock.Contains(2),
+			[]e{[]int{1, 2}, [2]int{2, 3}, map[string]int{"a": 2}},
+			[]e{[]int{1, 3}, []int{}, map[int]int{2: 1}, 2, nil},
+		},
+		{
+			"test Contains matcher", gomock.Contains(gomock.Len(2)),
+			[]e{[]string{"a", "bc"}},
+			[]e{[]string{"a", "b"}},
+		},
+		{
+			"test Each", gomock.Each(gomock.Not(0)),
+			[]e{[]int{1, 2}, []int{}, map[string]int{"a": 1}},
+			[]e{[]int{1, 0}, map[string]int{"a": 0}, 1},
+		},
+		{
+			"test ElementsAre", gomock.ElementsAre(1, gomock.Any(), 3),
+			[]e{[]int{1, 2, 3}, [3]int{1, 5, 3}},
+			[]e{[]int{1, 2}, []int{1, 2, 3, 4}, []int{3, 2, 1}, map[int]int{0: 1, 1: 2, 2: 3}},
+		},
+		{
+			"test HasKey", gomock.HasKey("a"),
+			[]e{map[string]int{"a": 1}, map[string]bool{"a": false, "b": true}},
+			[]e{map[string]int{"b": 1}, map[int]int{}, []string{"a"}},
+		},
+		{
+			"test HasEntry", gomock.HasEntry("a", gomock.Not(1)),
+			[]e{map[string]int{"a": 2}},
+			[]e{map[string]int{"a": 1}, map[string]int{"b": 2}},
+		},
+		{
+			"test SubsetOf", gomock.SubsetOf([]int{1, 2, 3}),
+			[]e{[]int{}, []int{3, 1}, []int{1, 2, 3}, map[string]int{"a": 2}},
+			[]e{[]int{1, 1}, []int{4}, []int{1, 2, 3, 4}, 1},
+		},
+		{
+			"test SupersetOf", gomock.SupersetOf([]int{1, 2}),
+			[]e{[]int{1, 2}, []int{3, 2, 1}, [3]int{2, 1, 1}},
+			[]e{[]int{1}, []int{1, 3}, []int{}, 1},
+		},
+		{"test Gt", gomock.Gt(2), []e{3, age(3)}, []e{2, 1, int64(3), "3", nil}},
+		{"test Ge", gomock.Ge(2), []e{2, 3}, []e{1}},
+		{"test Lt", gomock.Lt("b"), []e{"a", ""}, []e{"b", "c", []byte("a")}},
+		{"test Le", gomock.Le(2.5), []e{2.5, 1.0}, []e{3.0, float32(1)}},
+		{"test Between", gomock.Between[int64](1, 3), []e{int64(1), int64(3)}, []e{int64(0), int64(4), 2}},
+		{"test ApproxEq", gomock.ApproxEq(0.3, 1e-6), []e{0.1 + 0.2, float32(0.3)}, []e{0.31, 1, "0.3"}},
+		{"test HasPrefix", gomock.HasPrefix("foo"), []e{"foobar", []byte("foo")}, []e{"barfoo", []byte("fo"), 1}},
+		{"test HasSuffix", gomock.HasSuffix("bar"), []e{"foobar", []byte("bar")}, []e{"barfoo", 1}},
+		{"test Contains substring", gomock.Contains("oba"), []e{"foobar", []byte("foobar"), []string{"oba"}}, []e{"foo", []string{"foobar"}}},
+		{"test EqualFold", gomock.EqualFold("Go"), []e{"GO", []byte("go")}, []e{"Golang", 1}},
+		{
+			"test JSONEq key order and whitespace", gomock.JSONEq(`{"a": 1, "b": [true, null]}`),
+			[]e{`{"b":[true,null],"a":1}`, []byte(`{"a":1.0,"b":[true,null]}`), json.RawMessage(`{"b": [true, null], "a": 1}`)},
+			[]e{`{"a":1,"b":[null,true]}`, `{"a":1}`, `{"a":1,"b":[true,null],"c":1}`, `{"a":"1","b":[true,null]}`, `not json`, 1, nil},
+		},
+		{
+			"test JSONEq marshaled value", gomock.JSONEq(map[string]any{"a": []int{1, 2}}),
+			[]e{`{"a":[1,2]}`},
+			[]e{`{"a":[2,1]}`},
+		},
+		{
+			"test JSONEq ignored paths", gomock.JSONEq(`{"meta":{"requestId":"x","v":1},"items":[{"id":1,"n":"a"}]}`, "$.meta.requestId", "$.items[*].id"),
+			[]e{`{"meta":{"requestId":"y","v":1},"items":[{"id":2,"n":"a"}]}`, `{"meta":{"v":1},"items":[{"n":"a"}]}`},
+			[]e{`{"meta":{"requestId":"y","v":2},"items":[{"id":2,"n":"a"}]}`, `{"meta":{"v":1},"items":[{"n":"b"}]}`},
+		},
+		{
+			"test ErrorIs", gomock.ErrorIs(io.EOF),
+			[]e{io.EOF, fmt.Errorf("read: %w", io.EOF)},
+			[]e{errors.New("EOF"), nil, "EOF"},
+		},
+		{
+			"test ErrorIs through PathError", gomock.ErrorIs(fs.ErrNotExist),
+			[]e{pathErr, fmt.Errorf("load: %w", pathErr)},
+			[]e{fs.ErrExist},
+		},
+		{
+			"test ErrorIs nil", gomock.ErrorIs(nil),
+			[]e{nil},
+			[]e{io.EOF, (*fs.PathError)(nil), ""},
+		},
+		{
+			"test ErrorAs", gomock.ErrorAs[*fs.PathError](),
+			[]e{pathErr, fmt.Errorf("load: %w", pathErr)},
+			[]e{io.EOF, nil, pathErr.Op},
+		},
+		{
+			"test ErrorAs with matcher", gomock.ErrorAs[*fs.PathError](gomock.Field("Op", "open")),
+			[]e{fmt.Errorf("load: %w", pathErr)},
+			[]e{&fs.PathError{Op: "read"}},
+		},
+		{
+			"test ErrorContains", gomock.ErrorContains("timeout"),
+			[]e{errors.New("dial: timeout"), fmt.Errorf("x: %w", errors.New("timeout"))},
+			[]e{errors.New("refused"), nil, "timeout"},
+		},
+		{"test CtxValue", gomock.CtxValue(valueKey("user"), "alice"), []e{withValue, withDeadline}, []e{bg, "alice", nil}},
+		{"test CtxValue matcher", gomock.CtxValue(valueKey("user"), gomock.HasPrefix("a")), []e{withValue}, []e{bg}},
+		{"test CtxValue nil", gomock.CtxValue(valueKey("user"), nil), []e{bg}, []e{withValue}},
+		{"test CtxHasDeadline", gomock.CtxHasDeadline(), []e{withDeadline}, []e{bg, withValue, nil}},
+		{"test CtxDeadlineWithin", gomock.CtxDeadlineWithin(2 * time.Hour), []e{withDeadline}, []e{bg}},
+		{"test CtxDeadlineWithin too late", gomock.CtxDeadlineWithin(time.Minute), nil, []e{withDeadline}},
+		{"test CtxNotDone", gomock.CtxNotDone(), []e{bg, withDeadline}, []e{canceled, nil}},
+		{"test EqT", gomock.EqT(5), []e{5}, []e{4, int64(5), "5"}},
+		{"test EqT nil", gomock.EqT[error](nil), []e{nil, (*gomock.Call)(nil)}, []e{errors.New("err")}},
+		{"test AnyT", gomock.AnyT[string](), []e{"", "a", 5}, nil},
+		{"test NilT", gomock.NilT[*B](), []e{nil, (*B)(nil)}, []e{&B{}}},
+		{"test CondT", short, []e{"abc"}, []e{"abcd", 3}},
+		{
+			"test CheckT", gomock.CheckT(func(s string) error {
+				if s == "" {
+					return errors.New("empty")
+				}
+				return nil
+			}),
+			[]e{"a"}, []e{""},
+		},
+		{"test NotT", gomock.NotT(short), []e{"abcd"}, []e{"abc"}},
+		{"test AllT", gomock.AllT(short, gomock.Typed[string](gomock.Regex("^a"))), []e{"abc"}, []e{"bc", "abcd"}},
+		{"test AnyOfT", gomock.AnyOfT(short, gomock.EqT("abcd")), []e{"abc", "abcd"}, []e{"abcde"}},
+		{"test Typed", gomock.Typed[[]int](gomock.Len(2)), []e{[]int{1, 2}}, []e{[]int{1}}},
+		{"test Captor", gomock.TypedMatcher[string](gomock.NewCaptor[string]()), []e{"a"}, []e{1}},
+		{"test VariadicT", gomock.VariadicT(gomock.Typed[[]string](gomock.Len(2))), []e{[]string{"a", "b"}}, []e{"ab", []string{"a"}, []any{"a", "b"}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -103,6 +248,51 @@ func TestNotMatcher(t *testing.T) {
 	}
 }
 
+func TestExplainingMatchers(t *testing.T) {
+	even := gomock.Check(func(x int) error {
+		if x%2 != 0 {
+			return fmt.Errorf("%d is odd", x)
+		}
+		return nil
+	})
+	tests := []struct {
+		name       string
+		matcher    gomock.Matcher
+		x          any
+		wantMatch  bool
+		wantReason string
+	}{
+		{"Check matches", even, 2, true, ""},
+		{"Check reports error", even, 3, false, "3 is odd"},
+		{"Check reports wrong type", even, "2", false, "string is not a int"},
+		{"All matches", gomock.All(even, gomock.Gt(1)), 4, true, ""},
+		{
+			"All names failing matcher", gomock.All(gomock.Gt(1), even), 3, false,
+			`it doesn't match "passes a custom check": 3 is odd`,
+		},
+		{
+			"AnyOf lists every matcher", gomock.AnyOf(1, even), 3, false,
+			`it matches none of "is equal to 1 (int)"; "passes a custom check": 3 is odd`,
+		},
+		{"Not names inner matcher", gomock.Not(even), 2, false, `it matches "passes a custom check"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			em, ok := tt.matcher.(gomock.ExplainingMatcher)
+			if !ok {
+				t.Fatalf("%T does not implement ExplainingMatcher", tt.matcher)
+			}
+			match, reason := em.MatchExplain(tt.x)
+			if match != tt.wantMatch {
+				t.Errorf("match = %v, want %v", match, tt.wantMatch)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
 // A more thorough test of regexMatcher
 func TestRegexMatcher(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,82 @@
+package gomock
+
+import "fmt"
+
+// oneOfGroup is a group of alternative calls, of which only one may be
+// made, as declared with OneOf.
+type oneOfGroup struct {
+	calls  []*Call
+	origin string
+}
+
+// OneOf declares that exactly one of the given calls is expected. Once any
+// of the calls has been made, the other calls fail if they are made, and the
+// group is satisfied once that call is. If none of them is satisfied, Finish
+// reports the whole group as missing. It returns the calls as an Ordering,
+// so that the group can be passed to InOrder, Ordered and AnyOrder.
+// It panics if the type of any of the arguments isn't *Call or a generated
+// mock with an embedded *Call, or if a call is already in a OneOf group.
+//
+// Example usage:
+//
+//	gomock.OneOf(
+//	    mockCache.EXPECT().Get("key").Return(value, nil),
+//	    mockDB.EXPECT().Load("key").Return(value, nil),
+//	)
+func OneOf(args ...any) *Ordering {
+	g := &oneOfGroup{calls: make([]*Call, 0, len(args)), origin: callerInfo(1)}
+	for i, arg := range args {
+		call := getCall(arg)
+		if call == nil {
+			panic(fmt.Sprintf(
+				"invalid argument at position %d of type %T, OneOf expects *gomock.Call or generated mock types with an embedded *gomock.Call",
+				i,
+				arg,
+			))
+		}
+		g.calls = append(g.calls, call)
+	}
+	for i, call := range g.calls {
+		if call.group != nil {
+			panic(fmt.Sprintf(
+				"invalid argument at position %d, %v is already in the OneOf group at %s",
+				i,
+				call,
+				call.group.origin,
+			))
+		}
+		call.group = g
+	}
+	return &Ordering{first: g.calls, last: g.calls}
+}
+
+// chosen returns the call of the group, other than c, that was already
+// made, or nil if there is none.
+func (g *oneOfGroup) chosen(c *Call) *Call {
+	for _, call := range g.calls {
+		if call != c && call.numCalls > 0 {
+			return call
+		}
+	}
+	return nil
+}
+
+// satisfied returns whether any call of the group is satisfied.
+func (g *oneOfGroup) satisfied() bool {
+	for _, call := range g.calls {
+		if call.ownSatisfied() {
+			return true
+		}
+	}
+	return false
+}
+
+// missingGroupReport explains why none of the calls of g is satisfied.
+// ctrl.mu must be held.
+func (ctrl *Controller) missingGroupReport(g *oneOfGroup) string {
+	var s string
+	for _, call := range g.calls {
+		s += "\n" + indent(fmt.Sprintf("%v%s", call, ctrl.missingCallReport(call)), "  ")
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package gomock
+
+import "fmt"
+
+// An Ordering is a group of calls whose order was set with Ordered or
+// AnyOrder. It can be passed to InOrder, Ordered and AnyOrder in place of a
+// call, to nest orderings.
+type Ordering struct {
+	// first are the calls that may happen first, and last the calls that
+	// may happen last.
+	first, last []*Call
+}
+
+// AnyOrder groups calls that may occur in any order, so that they can be
+// ordered as a whole with InOrder. Like InOrder, it accepts calls and
+// Orderings, and panics if the type of any of the arguments isn't *Call,
+// *Ordering or a generated mock with an embedded *Call.
+//
+// Example usage:
+//
+//	// b and c may occur in either order, but both after a and before d.
+//	gomock.InOrder(a, gomock.AnyOrder(b, c), d)
+func AnyOrder(args ...any) *Ordering {
+	o := &Ordering{}
+	for _, step := range orderings("AnyOrder", args) {
+		o.first = append(o.first, step.first...)
+		o.last = append(o.last, step.last...)
+	}
+	return o
+}
+
+// orderings returns args, which are passed to fname, as Orderings. Empty
+// Orderings are left out, since there is nothing to order them by.
+func orderings(fname string, args []any) []*Ordering {
+	steps := make([]*Ordering, 0, len(args))
+	for i, arg := range args {
+		if o, ok := arg.(*Ordering); ok {
+			if len(o.first) != 0 {
+				steps = append(steps, o)
+			}
+			continue
+		}
+		if call := getCall(arg); call != nil {
+			steps = append(steps, &Ordering{first: []*Call{call}, last: []*Call{call}})
+			continue
+		}
+		panic(fmt.Sprintf(
+			"invalid argument at position %d of type %T, %s expects *gomock.Call, *gomock.Ordering or generated mock types with an embedded *gomock.Call",
+			i,
+			arg,
+			fname,
+		))
+	}
+	return steps
+}
+
+// A Sequence is a named ordering of calls. Calls join a Sequence with
+// Call.InSequence, and may then only occur after the call that joined it
+// before them. Since a call can join several Sequences, they describe
+// orderings that a single InOrder can't, e.g. a call that must follow both
+// a write and a read that are unordered among themselves.
+//
+// Example usage:
+//
+//	writes, reads := gomock.NewSequence("writes"), gomock.NewSequence("reads")
+//	mockObj.EXPECT().Write().InSequence(writes)
+//	mockObj.EXPECT().Read().InSequence(reads)
+//	mockObj.EXPECT().Close().InSequence(writes, reads)
+type Sequence struct {
+	name string
+	last *Call
+}
+
+// NewSequence returns a new, empty Sequence. Its name appears in the
+// failure messages of the calls that occur out of order.
+func NewSequence(name string) *Sequence {
+	return &Sequence{name: name}
+}
+
+// InSequence adds the call to the end of each of the given Sequences. The
+// call may only match after the calls that were added to them before.
+func (c *Call) InSequence(seqs ...*Sequence) *Call {
+	c.t.Helper()
+
+	for _, s := range seqs {
+		if s.last != nil {
+			c.after(s.last, fmt.Sprintf("sequence %q", s.name))
+		}
+		s.last = c
+	}
+	return c
+}
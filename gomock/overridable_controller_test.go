@@ -32,3 +32,19 @@ func TestEcho_WithOverride_BaseCase(t *testing.T) {
 		t.Fatalf("expected response to equal 'bar', got %s", res)
 	}
 }
+
+func TestEcho_WithOverride_Stub(t *testing.T) {
+	ctrl := gomock.NewController(t, gomock.WithOverridableExpectations())
+	mockIndex := NewMockFoo(ctrl)
+
+	mockIndex.EXPECT().Bar("input").Return("foo")
+	// a stub doesn't override the expectation above
+	ctrl.Stub(mockIndex.EXPECT().Bar(gomock.Any())).Return("bar")
+
+	if res := mockIndex.Bar("input"); res != "foo" {
+		t.Fatalf("expected response to equal 'foo', got %s", res)
+	}
+	if res := mockIndex.Bar("input"); res != "bar" {
+		t.Fatalf("expected response to equal 'bar', got %s", res)
+	}
+}
@@ -0,0 +1,216 @@
+package gomock
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+type orderedMatcher[T cmp.Ordered] struct {
+	desc string
+	fn   func(x T) bool
+}
+
+func (o orderedMatcher[T]) Matches(x any) bool {
+	v, ok := toOrdered[T](x)
+	return ok && o.fn(v)
+}
+
+func (o orderedMatcher[T]) String() string {
+	return o.desc
+}
+
+// toOrdered converts x to T. Values of a different type with the same
+// underlying kind, e.g. of a named integer type, are converted as well.
+func toOrdered[T cmp.Ordered](x any) (T, bool) {
+	if v, ok := x.(T); ok {
+		return v, true
+	}
+	var zero T
+	want := reflect.TypeFor[T]()
+	v := reflect.ValueOf(x)
+	if !v.IsValid() || v.Kind() != want.Kind() {
+		return zero, false
+	}
+	return v.Convert(want).Interface().(T), true
+}
+
+type approxEqMatcher struct {
+	x, epsilon float64
+}
+
+func (a approxEqMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+		return false
+	}
+	return math.Abs(v.Float()-a.x) <= a.epsilon
+}
+
+func (a approxEqMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", a.epsilon, a.x)
+}
+
+type stringMatcher struct {
+	desc string
+	fn   func(x string) bool
+}
+
+func (s stringMatcher) Matches(x any) bool {
+	switch t := x.(type) {
+	case string:
+		return s.fn(t)
+	case []byte:
+		return s.fn(string(t))
+	default:
+		return false
+	}
+}
+
+func (s stringMatcher) String() string {
+	return s.desc
+}
+
+// Gt returns a matcher that matches values greater than x. The values must
+// be of the same kind as x; values of named types are converted.
+//
+// Example usage:
+//
+//	Gt(2).Matches(3) // returns true
+//	Gt(2).Matches(2) // returns false
+//	Gt(2).Matches(int64(3)) // returns false, use Gt[int64](2)
+func Gt[T cmp.Ordered](x T) Matcher {
+	return orderedMatcher[T]{
+		desc: fmt.Sprintf("is greater than %v (%T)", x, x),
+		fn:   func(v T) bool { return v > x },
+	}
+}
+
+// Ge returns a matcher that matches values greater than or equal to x. The
+// values must be of the same kind as x; values of named types are
+// converted.
+//
+// Example usage:
+//
+//	Ge(2).Matches(2) // returns true
+//	Ge(2).Matches(1) // returns false
+func Ge[T cmp.Ordered](x T) Matcher {
+	return orderedMatcher[T]{
+		desc: fmt.Sprintf("is greater than or equal to %v (%T)", x, x),
+		fn:   func(v T) bool { return v >= x },
+	}
+}
+
+// Lt returns a matcher that matches values less than x. The values must be
+// of the same kind as x; values of named types are converted.
+//
+// Example usage:
+//
+//	Lt("b").Matches("a") // returns true
+//	Lt("b").Matches("b") // returns false
+func Lt[T cmp.Ordered](x T) Matcher {
+	return orderedMatcher[T]{
+		desc: fmt.Sprintf("is less than %v (%T)", x, x),
+		fn:   func(v T) bool { return v < x },
+	}
+}
+
+// Le returns a matcher that matches values less than or equal to x. The
+// values must be of the same kind as x; values of named types are
+// converted.
+//
+// Example usage:
+//
+//	Le(2.5).Matches(2.5) // returns true
+//	Le(2.5).Matches(3.0) // returns false
+func Le[T cmp.Ordered](x T) Matcher {
+	return orderedMatcher[T]{
+		desc: fmt.Sprintf("is less than or equal to %v (%T)", x, x),
+		fn:   func(v T) bool { return v <= x },
+	}
+}
+
+// Between returns a matcher that matches values in the closed interval
+// [lo, hi]. The values must be of the same kind as lo and hi; values of
+// named types are converted.
+//
+// Example usage:
+//
+//	Between(1, 3).Matches(3) // returns true
+//	Between(1, 3).Matches(4) // returns false
+func Between[T cmp.Ordered](lo, hi T) Matcher {
+	return orderedMatcher[T]{
+		desc: fmt.Sprintf("is between %v and %v (%T)", lo, hi, lo),
+		fn:   func(v T) bool { return lo <= v && v <= hi },
+	}
+}
+
+// ApproxEq returns a matcher that matches float32 and float64 values which
+// differ from x by at most epsilon.
+//
+// Example usage:
+//
+//	ApproxEq(0.3, 1e-9).Matches(0.1 + 0.2) // returns true
+//	ApproxEq(0.3, 1e-9).Matches(0.31) // returns false
+func ApproxEq(x, epsilon float64) Matcher {
+	return approxEqMatcher{x: x, epsilon: epsilon}
+}
+
+// HasPrefix returns a matcher that matches strings and byte slices which
+// begin with prefix.
+//
+// Example usage:
+//
+//	HasPrefix("foo").Matches("foobar") // returns true
+//	HasPrefix("foo").Matches([]byte("foobar")) // returns true
+//	HasPrefix("foo").Matches("barfoo") // returns false
+func HasPrefix(prefix string) Matcher {
+	return stringMatcher{
+		desc: fmt.Sprintf("has prefix %q", prefix),
+		fn:   func(x string) bool { return strings.HasPrefix(x, prefix) },
+	}
+}
+
+// HasSuffix returns a matcher that matches strings and byte slices which
+// end with suffix.
+//
+// Example usage:
+//
+//	HasSuffix("bar").Matches("foobar") // returns true
+//	HasSuffix("bar").Matches("barfoo") // returns false
+func HasSuffix(suffix string) Matcher {
+	return stringMatcher{
+		desc: fmt.Sprintf("has suffix %q", suffix),
+		fn:   func(x string) bool { return strings.HasSuffix(x, suffix) },
+	}
+}
+
+// EqualFold returns a matcher that matches strings and byte slices which
+// are equal to s under Unicode case-folding.
+//
+// Example usage:
+//
+//	EqualFold("Go").Matches("GO") // returns true
+//	EqualFold("Go").Matches("Golang") // returns false
+func EqualFold(s string) Matcher {
+	return stringMatcher{
+		desc: fmt.Sprintf("is equal to %q ignoring case", s),
+		fn:   func(x string) bool { return strings.EqualFold(x, s) },
+	}
+}
+
+// containsSubstring reports whether x is a string or a byte slice that
+// contains substr. ok is false if x is neither.
+func containsSubstring(x any, substr string) (contains, ok bool) {
+	switch t := x.(type) {
+	case string:
+		return strings.Contains(t, substr), true
+	case []byte:
+		return bytes.Contains(t, []byte(substr)), true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,20 @@
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestScalarMatchers_String(t *testing.T) {
+	assertEqual(t, "is greater than 2 (int)", gomock.Gt(2).String())
+	assertEqual(t, "is greater than or equal to 2 (int)", gomock.Ge(2).String())
+	assertEqual(t, "is less than b (string)", gomock.Lt("b").String())
+	assertEqual(t, "is less than or equal to 2.5 (float64)", gomock.Le(2.5).String())
+	assertEqual(t, "is between 1 and 3 (int)", gomock.Between(1, 3).String())
+	assertEqual(t, "is within 0.01 of 0.3", gomock.ApproxEq(0.3, 0.01).String())
+	assertEqual(t, `has prefix "foo"`, gomock.HasPrefix("foo").String())
+	assertEqual(t, `has suffix "bar"`, gomock.HasSuffix("bar").String())
+	assertEqual(t, `contains "oba"`, gomock.Contains("oba").String())
+	assertEqual(t, `is equal to "Go" ignoring case`, gomock.EqualFold("Go").String())
+}
@@ -0,0 +1,259 @@
+package gomock
+
+import (
+	"maps"
+	"slices"
+)
+
+// Scope returns a child Controller for the subtest t, which layers its own
+// expectations over the ones of ctrl. While the scope is active, the
+// expectations recorded through the mocks of ctrl go to the scope, and the
+// calls to the mocks match the expectations of the scope before the ones of
+// ctrl. Unexpected calls are reported to t.
+//
+// Passing a [*testing.T] registers a cleanup function that verifies the
+// expectations of the scope, like [Controller.Finish], when the subtest
+// completes. Otherwise, Finish must be called on the scope. Either way, ctrl
+// then gets back the expectations it had when the scope was created, even
+// the ones the subtest called.
+//
+// Scopes can be nested, but only the innermost one is active, so subtests
+// using scopes of the same Controller must not run in parallel: Scope fails
+// the subtest t if ctrl already has an active scope. While a scope is
+// active, Checkpoint and Reset fail on its parent, and Finish on the parent
+// ends the scope first.
+//
+// Example usage:
+//
+//	ctrl := gomock.NewController(t)
+//	mockObj := NewMockStore(ctrl)
+//	mockObj.EXPECT().Get(gomock.Any()).Return(nil, ErrNotFound).AnyTimes()
+//	for _, tt := range tests {
+//	    t.Run(tt.name, func(t *testing.T) {
+//	        ctrl.Scope(t)
+//	        mockObj.EXPECT().Get(tt.key).Return(tt.value, nil)
+//	        // ...
+//	    })
+//	}
+func (ctrl *Controller) Scope(t TestReporter) *Controller {
+	ctrl.T.Helper()
+
+	h, ok := t.(TestHelper)
+	if !ok {
+		h = &nopTestHelper{t}
+	}
+
+	var expectedCalls *callSet
+	if ctrl.expectedCalls.allowOverride {
+		expectedCalls = newOverridableCallSet()
+	} else {
+		expectedCalls = newCallSet()
+	}
+	expectedCalls.newestFirst = ctrl.expectedCalls.newestFirst
+
+	scope := &Controller{
+		T:               h,
+		mu:              ctrl.mu,
+		expectedCalls:   expectedCalls,
+		unexpected:      ctrl.unexpected,
+		unexpectedStack: ctrl.unexpectedStack,
+		newestFirst:     ctrl.newestFirst,
+		finishTimeout:   ctrl.finishTimeout,
+		clock:           ctrl.clock,
+		equalities:      ctrl.equalities,
+		parent:          ctrl,
+	}
+
+	ctrl.mu.Lock()
+	root := ctrl.root()
+	if root.activeScope() != ctrl {
+		ctrl.mu.Unlock()
+		// Report to the new subtest, since ctrl's test may be running
+		// another one in parallel.
+		h.Helper()
+		h.Fatalf("gomock: Scope called on a Controller that has an active scope; " +
+			"subtests using scopes of the same Controller must not run in parallel")
+		return nil
+	}
+	for _, cs := range root.layers() {
+		scope.saved = append(scope.saved, cs.snapshot())
+	}
+	root.scopes = append(root.scopes, scope)
+	ctrl.mu.Unlock()
+
+	if c, ok := isCleanuper(scope.T); ok {
+		c.Cleanup(func() {
+			scope.T.Helper()
+			scope.finish(true, nil)
+		})
+	}
+	return scope
+}
+
+// root returns the Controller that ctrl is a scope of, directly or
+// indirectly, or ctrl itself if it isn't a scope.
+func (ctrl *Controller) root() *Controller {
+	for ctrl.parent != nil {
+		ctrl = ctrl.parent
+	}
+	return ctrl
+}
+
+// activeScope returns the innermost active scope of the root Controller
+// ctrl, or ctrl itself if there is none. ctrl.mu must be held.
+func (ctrl *Controller) activeScope() *Controller {
+	if len(ctrl.scopes) == 0 {
+		return ctrl
+	}
+	return ctrl.scopes[len(ctrl.scopes)-1]
+}
+
+// nestedScopes returns the active scopes nested in ctrl, outermost first.
+// ctrl.mu must be held.
+func (ctrl *Controller) nestedScopes() []*Controller {
+	root := ctrl.root()
+	if ctrl == root {
+		return root.scopes
+	}
+	i := slices.Index(root.scopes, ctrl)
+	if i < 0 {
+		return nil
+	}
+	return root.scopes[i+1:]
+}
+
+// hasActiveScope returns whether a scope nested in ctrl is active. ctrl.mu
+// must be held.
+func (ctrl *Controller) hasActiveScope() bool {
+	return len(ctrl.nestedScopes()) != 0
+}
+
+// ownLayers returns the callSet of ctrl, followed by the ones of the active
+// scopes nested in it. ctrl.mu must be held.
+func (ctrl *Controller) ownLayers() []*callSet {
+	sets := []*callSet{ctrl.expectedCalls}
+	for _, s := range ctrl.nestedScopes() {
+		sets = append(sets, s.expectedCalls)
+	}
+	return sets
+}
+
+// layers returns the callSets of the active scopes of the root Controller
+// ctrl, innermost first, followed by its own. ctrl.mu must be held.
+func (ctrl *Controller) layers() []*callSet {
+	sets := make([]*callSet, 0, len(ctrl.scopes)+1)
+	for i := len(ctrl.scopes) - 1; i >= 0; i-- {
+		sets = append(sets, ctrl.scopes[i].expectedCalls)
+	}
+	return append(sets, ctrl.expectedCalls)
+}
+
+// leaveScope deactivates ctrl, and any scope nested in it, and restores the
+// callSets it layered its expectations over. It does nothing if ctrl isn't
+// an active scope. ctrl.mu must be held.
+func (ctrl *Controller) leaveScope() {
+	if ctrl.parent == nil {
+		return
+	}
+	root := ctrl.root()
+	i := slices.Index(root.scopes, ctrl)
+	if i < 0 {
+		return
+	}
+	root.scopes = root.scopes[:i]
+	for _, s := range ctrl.saved {
+		s.restore()
+	}
+	root.notifyChanged()
+}
+
+// callSetState is a copy of a callSet and of the state of its calls, so
+// that they can be restored.
+type callSetState struct {
+	cs                         *callSet
+	expected, exhausted, stubs map[callSetKey][]*Call
+	overridden                 map[callSetKey]overriddenCalls
+	calls                      map[*Call]callState
+}
+
+// callState is the part of a Call that changes when it is called.
+type callState struct {
+	numCalls  int
+	preReqs   []*Call
+	sequences []int64
+}
+
+// snapshot returns a copy of cs and of the state of its calls.
+func (cs *callSet) snapshot() callSetState {
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	s := callSetState{
+		cs:         cs,
+		expected:   cloneCalls(cs.expected),
+		exhausted:  cloneCalls(cs.exhausted),
+		stubs:      cloneCalls(cs.stubs),
+		overridden: maps.Clone(cs.overridden),
+		calls:      make(map[*Call]callState),
+	}
+	for _, m := range []map[callSetKey][]*Call{cs.expected, cs.exhausted, cs.stubs} {
+		for _, calls := range m {
+			for _, c := range calls {
+				c.mu.Lock()
+				state := callState{numCalls: c.numCalls, preReqs: c.preReqs}
+				for _, next := range c.sequences {
+					state.sequences = append(state.sequences, next.Load())
+				}
+				s.calls[c] = state
+				c.mu.Unlock()
+			}
+		}
+	}
+	return s
+}
+
+// restore brings back the callSet and the calls to the state they had when
+// s was taken.
+func (s callSetState) restore() {
+	s.cs.expectedMu.Lock()
+	defer s.cs.expectedMu.Unlock()
+
+	replace(s.cs.expected, s.expected)
+	replace(s.cs.exhausted, s.exhausted)
+	replace(s.cs.stubs, s.stubs)
+	if s.cs.overridden != nil {
+		replace(s.cs.overridden, s.overridden)
+	}
+	for c, state := range s.calls {
+		c.mu.Lock()
+		c.numCalls, c.preReqs = state.numCalls, state.preReqs
+		for i, n := range state.sequences {
+			c.sequences[i].Store(n)
+		}
+		// A closed channel can't be reopened, so the calls that are no
+		// longer satisfied get a new one.
+		if c.done != nil && !c.ownSatisfied() {
+			select {
+			case <-c.done:
+				c.done = make(chan struct{})
+			default:
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// cloneCalls returns a copy of m whose slices can be modified independently.
+func cloneCalls(m map[callSetKey][]*Call) map[callSetKey][]*Call {
+	clone := make(map[callSetKey][]*Call, len(m))
+	for k, calls := range m {
+		clone[k] = slices.Clone(calls)
+	}
+	return clone
+}
+
+// replace replaces the contents of dst with the ones of src.
+func replace[K comparable, V any](dst, src map[K]V) {
+	clear(dst)
+	maps.Copy(dst, src)
+}
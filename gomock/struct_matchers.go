@@ -0,0 +1,198 @@
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type fieldMatcher struct {
+	path string
+	m    Matcher
+}
+
+func (f fieldMatcher) Matches(x any) bool {
+	v, err := fieldByPath(x, f.path)
+	if err != nil {
+		return false
+	}
+	return f.m.Matches(v)
+}
+
+func (f fieldMatcher) Got(got any) string {
+	v, err := fieldByPath(got, f.path)
+	if err != nil {
+		return fmt.Sprintf("%T, which %v", got, err)
+	}
+	return fmt.Sprintf("%s: %s", f.path, formatGottenArg(f.m, v))
+}
+
+func (f fieldMatcher) withEqualities(eqs equalities) Matcher {
+	return fieldMatcher{path: f.path, m: bindEqualities(f.m, eqs)}
+}
+
+func (f fieldMatcher) String() string {
+	return fmt.Sprintf("has field %s that %s", f.path, f.m)
+}
+
+// fieldByPath returns the value of the field of x at path, a dot-separated
+// list of field names. Pointers and interfaces are followed along the way.
+func fieldByPath(x any, path string) (any, error) {
+	v := reflect.ValueOf(x)
+	var walked []string
+	for _, name := range strings.Split(path, ".") {
+		v = indirect(v)
+		if !v.IsValid() {
+			if len(walked) == 0 {
+				return nil, fmt.Errorf("is nil")
+			}
+			return nil, fmt.Errorf("has nil field %s", strings.Join(walked, "."))
+		}
+		if v.Kind() != reflect.Struct {
+			if len(walked) == 0 {
+				return nil, fmt.Errorf("is not a struct")
+			}
+			return nil, fmt.Errorf("has non-struct field %s", strings.Join(walked, "."))
+		}
+		walked = append(walked, name)
+		sf, ok := v.Type().FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("has no field %s", strings.Join(walked, "."))
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("has unexported field %s", strings.Join(walked, "."))
+		}
+		v = v.FieldByIndex(sf.Index)
+	}
+	return v.Interface(), nil
+}
+
+// indirect follows pointers and interfaces until it reaches a value that is
+// neither. It returns the zero Value if it reaches a nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+type partialMatcher struct {
+	x   any
+	eqs equalities
+}
+
+func (p partialMatcher) Matches(x any) bool {
+	return len(p.mismatches(x)) == 0
+}
+
+func (p partialMatcher) Got(got any) string {
+	mismatches := p.mismatches(got)
+	if len(mismatches) == 0 {
+		return fmt.Sprintf("%v (%T)", got, got)
+	}
+	return fmt.Sprintf("%T with %s", got, strings.Join(mismatches, ", "))
+}
+
+func (p partialMatcher) withEqualities(eqs equalities) Matcher {
+	return partialMatcher{x: p.x, eqs: eqs}
+}
+
+func (p partialMatcher) String() string {
+	return fmt.Sprintf("has the non-zero fields of %+v (%T)", p.x, p.x)
+}
+
+// mismatches returns a description of each non-zero field of p.x which
+// differs in x. It returns a single description if x isn't of the same
+// struct type.
+func (p partialMatcher) mismatches(x any) []string {
+	want, got := indirect(reflect.ValueOf(p.x)), indirect(reflect.ValueOf(x))
+	if !got.IsValid() {
+		return []string{"nil value"}
+	}
+	if want.Type() != got.Type() {
+		return []string{fmt.Sprintf("type %v instead of %v", got.Type(), want.Type())}
+	}
+	var mismatches []string
+	p.compare("", got, want, &mismatches)
+	return mismatches
+}
+
+// compare appends to mismatches each non-zero field of want which differs
+// in got. Nested structs are compared the same way, unless their type has
+// its own notion of equality.
+func (p partialMatcher) compare(path string, got, want reflect.Value, mismatches *[]string) {
+	for i := 0; i < want.NumField(); i++ {
+		wf, gf := want.Field(i), got.Field(i)
+		if wf.IsZero() {
+			continue
+		}
+		name := path + want.Type().Field(i).Name
+		if wf.Kind() == reflect.Struct && p.comparesFields(wf.Type()) {
+			p.compare(name+".", gf, wf, mismatches)
+			continue
+		}
+		// A non-zero field of want is never nil, so a nil field of got is
+		// a mismatch, which the equality functions shouldn't be given.
+		if isNillable(gf.Type()) && gf.IsNil() {
+			*mismatches = append(*mismatches, fmt.Sprintf("%s: is nil (want %s)", name, formatValue(wf)))
+			continue
+		}
+		var same bool
+		if wf.CanInterface() {
+			same = equal(gf.Interface(), wf.Interface(), p.eqs)
+		} else {
+			d := &differ{visited: make(map[visit]bool)}
+			d.diff("", gf, wf)
+			same = len(d.lines) == 0
+		}
+		if !same {
+			*mismatches = append(*mismatches, fmt.Sprintf("%s: %s (want %s)", name, formatValue(gf), formatValue(wf)))
+		}
+	}
+}
+
+// comparesFields returns whether values of the struct type t should be
+// compared field by field, rather than as a whole.
+func (p partialMatcher) comparesFields(t reflect.Type) bool {
+	if _, ok := p.eqs.lookup(t, t); ok {
+		return false
+	}
+	if _, ok := equalMethod(t); ok {
+		return false
+	}
+	return !isOpaqueStruct(t)
+}
+
+// Field returns a matcher that matches structs, or pointers to structs,
+// whose field at path is matched by x. path is a field name, or a
+// dot-separated list of field names to reach a nested field. Pointers are
+// followed along the way. If x isn't a Matcher, it is compared with Eq.
+//
+// Example usage:
+//
+//	Field("Name", "foo").Matches(B{Name: "foo"}) // returns true
+//	Field("Spec.Owner.ID", Not(0)).Matches(&Resource{}) // returns false
+func Field(path string, x any) Matcher {
+	return fieldMatcher{path: path, m: toMatcher(x)}
+}
+
+// Partial returns a matcher that matches structs, or pointers to structs, of
+// the same type as x whose fields are equal to the non-zero fields of x.
+// Zero fields of x, like generated IDs or timestamps left out of the
+// expectation, are ignored. Non-zero nested structs are compared the same
+// way.
+//
+// Example usage:
+//
+//	Partial(B{Name: "foo"}).Matches(B{Name: "foo"}) // returns true
+//	Partial(Request{User: "foo"}).Matches(Request{ID: 42, User: "foo"}) // returns true
+//	Partial(Request{User: "foo"}).Matches(Request{ID: 42, User: "bar"}) // returns false
+func Partial(x any) Matcher {
+	if v := indirect(reflect.ValueOf(x)); !v.IsValid() || v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomock: Partial expects a struct or a pointer to a struct, got %T", x))
+	}
+	return partialMatcher{x: x}
+}
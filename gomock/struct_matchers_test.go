@@ -0,0 +1,115 @@
+package gomock_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+type (
+	owner struct {
+		ID   int
+		Name string
+	}
+	spec struct {
+		Owner *owner
+		Tags  []string
+	}
+	resource struct {
+		ID      int
+		Name    string
+		Spec    spec
+		Created time.Time
+		Err     error
+		secret  string
+	}
+)
+
+func TestFieldMatcher(t *testing.T) {
+	r := resource{ID: 1, Name: "r", Spec: spec{Owner: &owner{ID: 7}}}
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+		got     string
+	}{
+		{"top-level field", gomock.Field("Name", "r"), r, true, `Name: r (string)`},
+		{"nested path", gomock.Field("Spec.Owner.ID", 7), &r, true, `Spec.Owner.ID: 7 (int)`},
+		{"inner matcher", gomock.Field("Spec.Owner.ID", gomock.Not(7)), r, false, `Spec.Owner.ID: 7 (int)`},
+		{"nil field", gomock.Field("Spec.Owner.ID", 7), resource{}, false, "gomock_test.resource, which has nil field Spec.Owner"},
+		{"missing field", gomock.Field("Spec.Size", 7), r, false, "gomock_test.resource, which has no field Spec.Size"},
+		{"unexported field", gomock.Field("secret", ""), r, false, "gomock_test.resource, which has unexported field secret"},
+		{"not a struct", gomock.Field("Name", "r"), "r", false, "string, which is not a struct"},
+		{"nil", gomock.Field("Name", "r"), nil, false, "<nil>, which is nil"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("%v.Matches(%v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+			}
+			if got := tt.matcher.(gomock.GotFormatter).Got(tt.x); got != tt.got {
+				t.Errorf("Got(%v) = %q, want %q", tt.x, got, tt.got)
+			}
+		})
+	}
+	assertEqual(t, "has field Spec.Owner.ID that is equal to 7 (int)", gomock.Field("Spec.Owner.ID", 7).String())
+}
+
+func TestPartialMatcher(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := resource{
+		ID:      1,
+		Name:    "r",
+		Spec:    spec{Owner: &owner{ID: 7, Name: "o"}, Tags: []string{"a"}},
+		Created: now,
+		secret:  "s",
+	}
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+		got     string
+	}{
+		{"zero fields ignored", gomock.Partial(resource{Name: "r"}), r, true, ""},
+		{"pointers", gomock.Partial(&resource{Name: "r"}), &r, true, ""},
+		{"nested struct", gomock.Partial(resource{Spec: spec{Tags: []string{"a"}}}), r, true, ""},
+		{"time in another location", gomock.Partial(resource{Created: now.Local()}), r, true, ""},
+		{"unexported field", gomock.Partial(resource{secret: "s"}), r, true, ""},
+		{
+			"mismatch", gomock.Partial(resource{Name: "x", Spec: spec{Tags: []string{"b"}}}), r, false,
+			`gomock_test.resource with Name: "r" (want "x"), Spec.Tags: [a] (want [b])`,
+		},
+		{"other type", gomock.Partial(resource{Name: "r"}), owner{}, false, "gomock_test.owner with type gomock_test.owner instead of gomock_test.resource"},
+		{"nil", gomock.Partial(resource{Name: "r"}), (*resource)(nil), false, "*gomock_test.resource with nil value"},
+		{"nil error field", gomock.Partial(resource{Err: errors.New("x")}), r, false, "gomock_test.resource with Err: is nil (want x)"},
+		{"other error field", gomock.Partial(resource{Err: errors.New("x")}), resource{Err: errors.New("y")}, false, "gomock_test.resource with Err: y (want x)"},
+		{
+			"nil pointer field", gomock.Partial(resource{Spec: spec{Owner: &owner{ID: 7}}}), resource{}, false,
+			"gomock_test.resource with Spec.Owner: is nil (want &{7 })",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("%v.Matches(%v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+			}
+			if tt.got == "" {
+				return
+			}
+			if got := tt.matcher.(gomock.GotFormatter).Got(tt.x); got != tt.got {
+				t.Errorf("Got(%v) = %q, want %q", tt.x, got, tt.got)
+			}
+		})
+	}
+}
+
+func TestPartialMatcher_NotAStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Partial should panic when not given a struct")
+		}
+	}()
+	gomock.Partial(42)
+}
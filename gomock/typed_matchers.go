@@ -0,0 +1,155 @@
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A TypedMatcher is a Matcher for the arguments of type T. Mocks generated
+// with -typed and -typed_matchers take TypedMatchers of the parameter types,
+// so that passing a matcher for an argument of another type fails to
+// compile.
+//
+// TypedMatchers are built with the typed constructors, such as EqT, AnyT and
+// CondT, or by converting a Matcher with Typed.
+type TypedMatcher[T any] interface {
+	Matcher
+
+	// acceptsType restricts the implementations to the ones in this
+	// package, which are all tied to T.
+	acceptsType(T)
+}
+
+// typedMatcher makes a Matcher a TypedMatcher. It forwards the optional
+// interfaces of the matcher it wraps, so that failure messages are the same
+// as if the matcher had been used directly.
+type typedMatcher[T any] struct {
+	Matcher
+}
+
+func (typedMatcher[T]) acceptsType(T) {}
+
+func (m typedMatcher[T]) Got(got any) string {
+	return formatGottenArg(m.Matcher, got)
+}
+
+func (m typedMatcher[T]) Diff(got any) string {
+	if df, ok := m.Matcher.(DiffFormatter); ok {
+		return df.Diff(got)
+	}
+	return ""
+}
+
+func (m typedMatcher[T]) MatchExplain(x any) (bool, string) {
+	return explain(m.Matcher, x)
+}
+
+func (m typedMatcher[T]) withEqualities(eqs equalities) Matcher {
+	return typedMatcher[T]{bindEqualities(m.Matcher, eqs)}
+}
+
+func (m typedMatcher[T]) capture(x any) {
+	if c, ok := m.Matcher.(capturer); ok {
+		c.capture(x)
+	}
+}
+
+func (*Captor[T]) acceptsType(T) {}
+
+// Typed returns m as a matcher for the arguments of type T. It doesn't check
+// that m can match values of type T.
+//
+// Example usage:
+//
+//	Typed[string](Regex("^[a-z]+$")).Matches("abc") // returns true
+func Typed[T any](m Matcher) TypedMatcher[T] {
+	if tm, ok := m.(TypedMatcher[T]); ok {
+		return tm
+	}
+	return typedMatcher[T]{m}
+}
+
+// EqT is the typed version of Eq. It matches the arguments of type T that
+// are equal to x, just like passing x to a mock's recorder would.
+//
+// Example usage:
+//
+//	EqT(5).Matches(5) // returns true
+//	EqT(int64(5)).Matches(5) // returns false
+func EqT[T any](x T) TypedMatcher[T] {
+	return typedMatcher[T]{toMatcher(any(x))}
+}
+
+// AnyT is the typed version of Any.
+func AnyT[T any]() TypedMatcher[T] { return typedMatcher[T]{anyMatcher{}} }
+
+// NilT is the typed version of Nil.
+func NilT[T any]() TypedMatcher[T] { return typedMatcher[T]{nilMatcher{}} }
+
+// CondT is the typed version of Cond.
+func CondT[T any](fn func(x T) bool) TypedMatcher[T] {
+	return typedMatcher[T]{condMatcher[T]{fn}}
+}
+
+// CheckT is the typed version of Check.
+func CheckT[T any](fn func(x T) error) TypedMatcher[T] {
+	return typedMatcher[T]{checkMatcher[T]{fn}}
+}
+
+// NotT is the typed version of Not.
+func NotT[T any](m TypedMatcher[T]) TypedMatcher[T] {
+	return typedMatcher[T]{notMatcher{m}}
+}
+
+// AllT is the typed version of All.
+func AllT[T any](ms ...TypedMatcher[T]) TypedMatcher[T] {
+	return typedMatcher[T]{allMatcher{untyped(ms)}}
+}
+
+// AnyOfT is the typed version of AnyOf.
+func AnyOfT[T any](ms ...TypedMatcher[T]) TypedMatcher[T] {
+	return typedMatcher[T]{anyOfMatcher{untyped(ms)}}
+}
+
+// VariadicT returns m, a matcher for slices of T, as a matcher for the
+// variadic arguments of type T of a typed recorder method. Passed as the
+// only variadic argument, it is matched against all of them as a slice,
+// like a plain slice matcher is with untyped recorder methods.
+//
+// Example usage:
+//
+//	mockObj.EXPECT().Delete(gomock.VariadicT(gomock.Typed[[]string](gomock.Len(2))))
+func VariadicT[T any](m TypedMatcher[[]T]) TypedMatcher[T] {
+	return variadicMatcher[T]{typedMatcher[T]{m}}
+}
+
+// variadicMatcher only matches slices of T, so that it is never matched
+// against a single variadic argument.
+type variadicMatcher[T any] struct {
+	typedMatcher[T]
+}
+
+func (m variadicMatcher[T]) Matches(x any) bool {
+	_, ok := x.([]T)
+	return ok && m.Matcher.Matches(x)
+}
+
+func (m variadicMatcher[T]) MatchExplain(x any) (bool, string) {
+	if _, ok := x.([]T); !ok {
+		return false, fmt.Sprintf("%T is not a %v", x, reflect.TypeFor[[]T]())
+	}
+	return explain(m.Matcher, x)
+}
+
+func (m variadicMatcher[T]) withEqualities(eqs equalities) Matcher {
+	return variadicMatcher[T]{typedMatcher[T]{bindEqualities(m.Matcher, eqs)}}
+}
+
+// untyped returns ms as plain Matchers.
+func untyped[T any](ms []TypedMatcher[T]) []Matcher {
+	matchers := make([]Matcher, len(ms))
+	for i, m := range ms {
+		matchers[i] = m
+	}
+	return matchers
+}
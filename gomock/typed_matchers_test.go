@@ -0,0 +1,32 @@
+package gomock_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestTypedMatchers_KeepDescriptions(t *testing.T) {
+	m := gomock.Typed[B](gomock.Eq(B{Name: "Dam"}))
+	if got, want := m.String(), gomock.Eq(B{Name: "Dam"}).String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	df, ok := m.(gomock.DiffFormatter)
+	if !ok {
+		t.Fatal("Typed(Eq(...)) does not implement DiffFormatter")
+	}
+	if diff := df.Diff(B{Name: "Dave"}); !strings.Contains(diff, ".Name") {
+		t.Errorf("Diff() = %q, want it to mention .Name", diff)
+	}
+
+	em, ok := gomock.AllT(gomock.CheckT(func(B) error { return errors.New("no") })).(gomock.ExplainingMatcher)
+	if !ok {
+		t.Fatal("AllT(...) does not implement ExplainingMatcher")
+	}
+	if _, reason := em.MatchExplain(B{}); !strings.HasSuffix(reason, ": no") {
+		t.Errorf("MatchExplain() reason = %q, want it to end with %q", reason, ": no")
+	}
+}
@@ -0,0 +1,16 @@
+package typed_matchers
+
+//go:generate mockgen -package typed_matchers -source=input.go -destination=mock.go -typed -typed_matchers
+type Store interface {
+	Get(key string) (int64, error)
+	Put(key string, value int64) error
+	Delete(keys ...string) int
+}
+
+func Increment(s Store, key string) error {
+	v, err := s.Get(key)
+	if err != nil {
+		return err
+	}
+	return s.Put(key, v+1)
+}
@@ -0,0 +1,47 @@
+package typed_matchers
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestIncrement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockStore := NewMockStore(ctrl)
+	mockStore.EXPECT().Get(gomock.EqT("visits")).Return(41, nil)
+	mockStore.EXPECT().Put(gomock.EqT("visits"), gomock.CondT(func(v int64) bool { return v > 41 })).Return(nil)
+
+	if err := Increment(mockStore, "visits"); err != nil {
+		t.Fatalf("Increment() = %v, want nil", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockStore := NewMockStore(ctrl)
+	prefixed := gomock.CondT(func(key string) bool { return strings.HasPrefix(key, "tmp/") })
+	mockStore.EXPECT().Delete(gomock.EqT("a"), prefixed).Return(2)
+
+	if got := mockStore.Delete("a", "tmp/b"); got != 2 {
+		t.Errorf("Delete() = %d, want 2", got)
+	}
+}
+
+func TestDelete_AllKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockStore := NewMockStore(ctrl)
+	keys := gomock.NewCaptor[[]string]()
+	mockStore.EXPECT().Delete(gomock.VariadicT[string](keys)).Return(3)
+
+	if got := mockStore.Delete("a", "b", "c"); got != 3 {
+		t.Errorf("Delete() = %d, want 3", got)
+	}
+	if got := keys.Last(); len(got) != 3 {
+		t.Errorf("captured keys %v, want 3 of them", got)
+	}
+}
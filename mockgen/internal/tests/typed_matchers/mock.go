@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package typed_matchers -source=input.go -destination=mock.go -typed -typed_matchers
+//
+
+// Package typed_matchers is a generated GoMock package.
+package typed_matchers
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStore) Delete(keys ...string) int {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStoreMockRecorder) Delete(keys ...gomock.TypedMatcher[string]) *MockStoreDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), varargs...)
+	return &MockStoreDeleteCall{Call: call}
+}
+
+// MockStoreDeleteCall wrap *gomock.Call
+type MockStoreDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreDeleteCall) Return(arg0 int) *MockStoreDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreDeleteCall) Do(f func(...string) int) *MockStoreDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreDeleteCall) DoAndReturn(f func(...string) int) *MockStoreDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockStore) Get(key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(key gomock.TypedMatcher[string]) *MockStoreGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+	return &MockStoreGetCall{Call: call}
+}
+
+// MockStoreGetCall wrap *gomock.Call
+type MockStoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreGetCall) Return(arg0 int64, arg1 error) *MockStoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreGetCall) Do(f func(string) (int64, error)) *MockStoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreGetCall) DoAndReturn(f func(string) (int64, error)) *MockStoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Put mocks base method.
+func (m *MockStore) Put(key string, value int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStoreMockRecorder) Put(key gomock.TypedMatcher[string], value gomock.TypedMatcher[int64]) *MockStorePutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), key, value)
+	return &MockStorePutCall{Call: call}
+}
+
+// MockStorePutCall wrap *gomock.Call
+type MockStorePutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStorePutCall) Return(arg0 error) *MockStorePutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStorePutCall) Do(f func(string, int64) error) *MockStorePutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStorePutCall) DoAndReturn(f func(string, int64) error) *MockStorePutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
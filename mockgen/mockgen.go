@@ -67,6 +67,7 @@ var (
 	copyrightFile          = flag.String("copyright_file", "", "Copyright file used to add copyright header")
 	buildConstraint        = flag.String("build_constraint", "", "If non-empty, added as //go:build <constraint>")
 	typed                  = flag.Bool("typed", false, "Generate Type-safe 'Return', 'Do', 'DoAndReturn' function")
+	typedMatchers          = flag.Bool("typed_matchers", false, "With -typed, generate recorder methods that take gomock.TypedMatcher arguments instead of 'any'")
 	imports                = flag.String("imports", "", "(source mode) Comma-separated name=path pairs of explicit imports to use.")
 	auxFiles               = flag.String("aux_files", "", "(source mode) Comma-separated pkg=path pairs of auxiliary Go source files.")
 	modelGob               = flag.String("model_gob", "", "Skip package/source loading entirely and use the gob encoded model.Package at the given path")
@@ -81,6 +82,10 @@ func main() {
 
 	notifyAboutDeprecatedFlags()
 
+	if *typedMatchers && !*typed {
+		log.Fatal("-typed_matchers requires -typed")
+	}
+
 	if *showVersion {
 		printVersion()
 		return
@@ -550,7 +555,7 @@ func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePa
 	g.out()
 	g.p("}")
 
-	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed)
+	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed, *typedMatchers)
 
 	return nil
 }
@@ -561,13 +566,13 @@ func (b byMethodName) Len() int           { return len(b) }
 func (b byMethodName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
 func (b byMethodName) Less(i, j int) bool { return b[i].Name < b[j].Name }
 
-func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed bool) {
+func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed, typedMatchers bool) {
 	sort.Sort(byMethodName(intf.Methods))
 	for _, m := range intf.Methods {
 		g.p("")
 		_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp)
 		g.p("")
-		_ = g.GenerateMockRecorderMethod(intf, m, shortTp, typed)
+		_ = g.GenerateMockRecorderMethod(intf, m, pkgOverride, shortTp, typed, typedMatchers)
 		if typed {
 			g.p("")
 			_ = g.GenerateMockReturnCallMethod(intf, m, pkgOverride, longTp, shortTp)
@@ -655,25 +660,39 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 	return nil
 }
 
-func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, m *model.Method, shortTp string, typed bool) error {
+// GenerateMockRecorderMethod generates the recorder method of a mock
+// method. Its arguments are of type any, or, if typedMatchers is set, of
+// type gomock.TypedMatcher of the corresponding parameter type.
+func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, m *model.Method, pkgOverride, shortTp string, typed, typedMatchers bool) error {
 	mockType := g.mockName(intf.Name)
 	argNames := g.getArgNames(m, true)
 
 	var argString string
-	if m.Variadic == nil {
-		argString = strings.Join(argNames, ", ")
+	if typedMatchers {
+		argTypes := make([]string, len(argNames))
+		for i, p := range m.In {
+			argTypes[i] = fmt.Sprintf("gomock.TypedMatcher[%s]", p.Type.String(g.packageMap, pkgOverride))
+		}
+		if m.Variadic != nil {
+			argTypes[len(argTypes)-1] = fmt.Sprintf("...gomock.TypedMatcher[%s]", m.Variadic.Type.String(g.packageMap, pkgOverride))
+		}
+		argString = makeArgString(argNames, argTypes)
 	} else {
-		argString = strings.Join(argNames[:len(argNames)-1], ", ")
-	}
-	if argString != "" {
-		argString += " any"
-	}
-
-	if m.Variadic != nil {
+		if m.Variadic == nil {
+			argString = strings.Join(argNames, ", ")
+		} else {
+			argString = strings.Join(argNames[:len(argNames)-1], ", ")
+		}
 		if argString != "" {
-			argString += ", "
+			argString += " any"
+		}
+
+		if m.Variadic != nil {
+			if argString != "" {
+				argString += ", "
+			}
+			argString += fmt.Sprintf("%s ...any", argNames[len(argNames)-1])
 		}
-		argString += fmt.Sprintf("%s ...any", argNames[len(argNames)-1])
 	}
 
 	ia := newIdentifierAllocator(argNames)
@@ -694,6 +713,18 @@ func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, m *model.M
 		if len(argNames) > 0 {
 			callArgs = ", " + strings.Join(argNames, ", ")
 		}
+	} else if typedMatchers {
+		// The variadic matchers aren't of type any, so they have to be
+		// copied one by one.
+		idVarArgs := ia.allocateIdentifier("varargs")
+		idVArg := ia.allocateIdentifier("a")
+		g.p("%s := []any{%s}", idVarArgs, strings.Join(argNames[:len(argNames)-1], ", "))
+		g.p("for _, %s := range %s {", idVArg, argNames[len(argNames)-1])
+		g.in()
+		g.p("%s = append(%s, %s)", idVarArgs, idVarArgs, idVArg)
+		g.out()
+		g.p("}")
+		callArgs = ", " + idVarArgs + "..."
 	} else {
 		if len(argNames) == 1 {
 			// Easy: just use ... to push the arguments through.
@@ -87,3 +87,33 @@ func TestCancelWhenMocksSatisfied(t *testing.T) {
 	}
 	ctrl.Finish()
 }
+
+func TestWaitSatisfied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockMath(ctrl)
+	m.EXPECT().Sum(1, 2).Return(3)
+
+	go m.Sum(1, 2)
+
+	// WaitSatisfied blocks until every expected call has been made, without
+	// polling ctrl.Satisfied().
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ctrl.WaitSatisfied(ctx); err != nil {
+		t.Error("waiting for mocks to be satisfied:", err)
+	}
+}
+
+func TestWaitForCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockMath(ctrl)
+	sum := m.EXPECT().Sum(1, 2).Return(3)
+
+	go m.Sum(1, 2)
+
+	select {
+	case <-sum.Done():
+	case <-time.After(time.Second):
+		t.Error("timeout waiting for Sum to be called")
+	}
+}